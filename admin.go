@@ -0,0 +1,502 @@
+package jobrunner
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminServer is the settings of the embedded admin HTTP server exposing the health, status, errors and history of the application along with endpoints controlling it
+type AdminServer struct {
+	// Network is the network the admin server listens on, i.e. "tcp" or "unix"; empty means "tcp"
+	Network string
+	// Address is the address the admin server listens on, e.g. "127.0.0.1:8081" for localhost only, or the path of the socket file for a Unix socket; empty means no admin server
+	Address string
+	// Token is the bearer token required in the Authorization header of all requests except the health probes; empty means no authorization
+	Token string
+}
+
+// adminMessage is the response body of the admin endpoints not returning any data
+type adminMessage struct {
+	Message string `json:"message"`
+}
+
+// adminInstance is the status of an instance currently running, as reported by the admin server
+type adminInstance struct {
+	SessionID     uuid.UUID     `json:"sessionId"`
+	RoundID       uuid.UUID     `json:"roundId"`
+	Index         int           `json:"index"`
+	Reruns        int           `json:"reruns"`
+	Trigger       TriggerSource `json:"trigger"`
+	ScheduledTime time.Time     `json:"scheduledTime"`
+}
+
+// adminStatus is the status of an application, as reported by the admin server
+type adminStatus struct {
+	Name              string          `json:"name"`
+	Version           string          `json:"version"`
+	Running           bool            `json:"running"`
+	Paused            bool            `json:"paused"`
+	BreakerState      BreakerState    `json:"breakerState"`
+	NextScheduledTime *time.Time      `json:"nextScheduledTime,omitempty"`
+	RunningRounds     int             `json:"runningRounds"`
+	Instances         []adminInstance `json:"instances"`
+	Jobs              []string        `json:"jobs,omitempty"`
+}
+
+// getAdminStatus takes the snapshot of the status of the application, including its instances currently running and the jobs hosted if any
+func getAdminStatus(app *application) adminStatus {
+	app.lock.Lock()
+	var status = adminStatus{
+		Name:          app.name,
+		Version:       app.version,
+		Paused:        app.paused,
+		BreakerState:  app.breakerState,
+		RunningRounds: len(app.rounds),
+		Instances:     []adminInstance{},
+	}
+	if !app.nextRun.IsZero() {
+		var nextRun = app.nextRun
+		status.NextScheduledTime = &nextRun
+	}
+	for _, session := range app.inflight {
+		status.Instances = append(
+			status.Instances,
+			adminInstance{
+				SessionID:     session.id,
+				RoundID:       session.round.id,
+				Index:         session.index,
+				Reruns:        session.reruns,
+				Trigger:       session.round.trigger,
+				ScheduledTime: session.round.scheduled,
+			},
+		)
+	}
+	for _, job := range app.jobs {
+		status.Jobs = append(
+			status.Jobs,
+			job.name,
+		)
+	}
+	app.lock.Unlock()
+	slices.SortFunc(
+		status.Instances,
+		func(left adminInstance, right adminInstance) int {
+			var compared = left.ScheduledTime.Compare(
+				right.ScheduledTime,
+			)
+			if compared != 0 {
+				return compared
+			}
+			return left.Index - right.Index
+		},
+	)
+	status.Running = app.IsRunning()
+	return status
+}
+
+// writeAdminJSON writes the given value as the JSON response body with the given status code
+func writeAdminJSON(responseWriter http.ResponseWriter, statusCode int, value any) {
+	var data, marshalError = json.Marshal(
+		value,
+	)
+	if marshalError != nil {
+		http.Error(
+			responseWriter,
+			marshalError.Error(),
+			http.StatusInternalServerError,
+		)
+		return
+	}
+	responseWriter.Header().Set("Content-Type", "application/json")
+	responseWriter.WriteHeader(statusCode)
+	responseWriter.Write(data)
+}
+
+// authorizeAdmin wraps the handler to require the bearer token in the Authorization header of the request, unless the token is empty
+func authorizeAdmin(token string, handler http.HandlerFunc) http.HandlerFunc {
+	if token == "" {
+		return handler
+	}
+	var expected = []byte("Bearer " + token)
+	return func(responseWriter http.ResponseWriter, request *http.Request) {
+		if subtle.ConstantTimeCompare(
+			[]byte(request.Header.Get("Authorization")),
+			expected,
+		) != 1 {
+			responseWriter.Header().Set("WWW-Authenticate", "Bearer")
+			writeAdminJSON(
+				responseWriter,
+				http.StatusUnauthorized,
+				adminMessage{Message: "Unauthorized"},
+			)
+			return
+		}
+		handler(
+			responseWriter,
+			request,
+		)
+	}
+}
+
+// resolveAdminTarget returns the job named by the job query parameter of the request if given, or the application itself otherwise; a not found response is written if no job is registered with the name
+func resolveAdminTarget(app *application, responseWriter http.ResponseWriter, request *http.Request) (*application, bool) {
+	var name = request.URL.Query().Get("job")
+	if name == "" {
+		return app, true
+	}
+	var job = findJob(
+		app,
+		name,
+	)
+	if job == nil {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusNotFound,
+			adminMessage{Message: fmt.Sprintf("Job [%v] not found", name)},
+		)
+		return nil, false
+	}
+	return job, true
+}
+
+// resolveAdminControlTarget returns the target of a control endpoint the same way as resolveAdminTarget does, except that a runner could not be controlled as a whole, as its rounds are executed by its jobs; a bad request response is written if no job is given for a runner
+func resolveAdminControlTarget(app *application, responseWriter http.ResponseWriter, request *http.Request) (*application, bool) {
+	var target, found = resolveAdminTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return nil, false
+	}
+	if target.jobs != nil {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusBadRequest,
+			adminMessage{Message: fmt.Sprintf("Parameter [job] is required to control runner [%v]", target.name)},
+		)
+		return nil, false
+	}
+	return target, true
+}
+
+func serveHealthz(responseWriter http.ResponseWriter, request *http.Request) {
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		adminMessage{Message: "OK"},
+	)
+}
+
+func serveReadyz(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	if !app.IsRunning() ||
+		app.scheduling.Err() != nil {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusServiceUnavailable,
+			adminMessage{Message: "Not ready"},
+		)
+		return
+	}
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		adminMessage{Message: "Ready"},
+	)
+}
+
+func serveStatus(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	var target, found = resolveAdminTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return
+	}
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		getAdminStatus(target),
+	)
+}
+
+func serveErrors(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	var target, found = resolveAdminTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return
+	}
+	var since = request.URL.Query().Get("since")
+	if since == "" {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusOK,
+			target.RunErrors(),
+		)
+		return
+	}
+	var sinceTime, parseError = time.Parse(
+		time.RFC3339,
+		since,
+	)
+	if parseError != nil {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusBadRequest,
+			adminMessage{Message: fmt.Sprintf("Invalid since [%v]: %v", since, parseError)},
+		)
+		return
+	}
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		target.ErrorsSince(sinceTime),
+	)
+}
+
+func serveRuns(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	var target, found = resolveAdminTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return
+	}
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		target.History(),
+	)
+}
+
+func serveTrigger(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	var target, found = resolveAdminControlTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return
+	}
+	var reason = request.URL.Query().Get("reason")
+	if reason == "" {
+		reason = "Triggered through admin server"
+	}
+	var triggerError = target.TriggerNow(
+		reason,
+	)
+	if triggerError != nil {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusConflict,
+			adminMessage{Message: triggerError.Error()},
+		)
+		return
+	}
+	writeAdminJSON(
+		responseWriter,
+		http.StatusAccepted,
+		adminMessage{Message: "Triggered"},
+	)
+}
+
+func servePause(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	var target, found = resolveAdminControlTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return
+	}
+	target.Pause()
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		adminMessage{Message: "Paused"},
+	)
+}
+
+func serveResume(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	var target, found = resolveAdminControlTarget(
+		app,
+		responseWriter,
+		request,
+	)
+	if !found {
+		return
+	}
+	target.Resume()
+	writeAdminJSON(
+		responseWriter,
+		http.StatusOK,
+		adminMessage{Message: "Resumed"},
+	)
+}
+
+// serveStop stops the whole application gracefully in background, as the admin server itself is closed along with the application
+func serveStop(app *application, responseWriter http.ResponseWriter, request *http.Request) {
+	if !app.IsRunning() {
+		writeAdminJSON(
+			responseWriter,
+			http.StatusConflict,
+			adminMessage{Message: fmt.Sprintf("Runner [%v] is not running", app.name)},
+		)
+		return
+	}
+	go app.StopGracefully(
+		app.customization.GracefulTimeout(),
+	)
+	writeAdminJSON(
+		responseWriter,
+		http.StatusAccepted,
+		adminMessage{Message: "Stopping"},
+	)
+}
+
+// newAdminHandler creates the handler serving the admin endpoints of the application, where all endpoints except the health probes require the bearer token if given
+func newAdminHandler(app *application, token string) http.Handler {
+	var bind = func(serve func(*application, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+		return authorizeAdmin(
+			token,
+			func(responseWriter http.ResponseWriter, request *http.Request) {
+				serve(
+					app,
+					responseWriter,
+					request,
+				)
+			},
+		)
+	}
+	var mux = http.NewServeMux()
+	mux.HandleFunc("GET /healthz", serveHealthz)
+	mux.HandleFunc("GET /readyz", func(responseWriter http.ResponseWriter, request *http.Request) {
+		serveReadyz(
+			app,
+			responseWriter,
+			request,
+		)
+	})
+	mux.HandleFunc("GET /status", bind(serveStatus))
+	mux.HandleFunc("GET /errors", bind(serveErrors))
+	mux.HandleFunc("GET /runs", bind(serveRuns))
+	mux.HandleFunc("POST /trigger", bind(serveTrigger))
+	mux.HandleFunc("POST /pause", bind(servePause))
+	mux.HandleFunc("POST /resume", bind(serveResume))
+	mux.HandleFunc("POST /stop", bind(serveStop))
+	mux.HandleFunc("GET /metrics", authorizeAdmin(token, serveMetrics))
+	return mux
+}
+
+// serveAdmin serves the admin endpoints on the listener until the admin server is closed
+func serveAdmin(app *application, server *http.Server, listener net.Listener) {
+	var serveError = server.Serve(
+		listener,
+	)
+	if errors.Is(serveError, http.ErrServerClosed) {
+		return
+	}
+	logAppRoot(
+		app.session,
+		"admin",
+		"serveAdmin",
+		"Admin server terminated unexpectedly. Error: %+v",
+		serveError,
+	)
+}
+
+// startAdminServer starts the admin server in background if customized; failing to listen on the customized address is recorded as a run error without stopping the application
+func startAdminServer(app *application) {
+	var settings = app.customization.AdminServer()
+	if settings.Address == "" {
+		return
+	}
+	var network = settings.Network
+	if network == "" {
+		network = "tcp"
+	}
+	var listener, listenError = net.Listen(
+		network,
+		settings.Address,
+	)
+	if listenError != nil {
+		logAppRoot(
+			app.session,
+			"admin",
+			"startAdminServer",
+			"Failed to start admin server on [%v] address [%v]. Error: %+v",
+			network,
+			settings.Address,
+			listenError,
+		)
+		recordError(
+			app,
+			newRunError(
+				nil,
+				-1,
+				0,
+				RunPhaseAdmin,
+				listenError,
+			),
+		)
+		return
+	}
+	app.admin = &http.Server{
+		Handler: newAdminHandler(
+			app,
+			settings.Token,
+		),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	logAppRoot(
+		app.session,
+		"admin",
+		"startAdminServer",
+		"Admin server listening on [%v] address [%v]",
+		network,
+		listener.Addr(),
+	)
+	go serveAdmin(
+		app,
+		app.admin,
+		listener,
+	)
+}
+
+// stopAdminServer closes the admin server if started
+func stopAdminServer(app *application) {
+	if app.admin == nil {
+		return
+	}
+	var closeError = app.admin.Close()
+	app.admin = nil
+	if closeError != nil {
+		logAppRoot(
+			app.session,
+			"admin",
+			"stopAdminServer",
+			"Failed to close admin server. Error: %+v",
+			closeError,
+		)
+		return
+	}
+	logAppRoot(
+		app.session,
+		"admin",
+		"stopAdminServer",
+		"Admin server closed",
+	)
+}
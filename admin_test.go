@@ -0,0 +1,1140 @@
+package jobrunner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestGetAdminStatus_Idle(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:     "some name",
+		version:  "some version",
+		rounds:   map[uuid.UUID]*round{},
+		inflight: map[uuid.UUID]*session{},
+	}
+
+	// SUT + act
+	var result = getAdminStatus(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Equal(t, adminStatus{
+		Name:      "some name",
+		Version:   "some version",
+		Instances: []adminInstance{},
+	}, result)
+}
+
+func TestGetAdminStatus_Running(t *testing.T) {
+	// arrange
+	var dummyNextRun = time.Now().Add(time.Minute)
+	var dummyRound1 = &round{id: uuid.New(), trigger: TriggerSourceScheduled, scheduled: time.Now().Add(-time.Minute)}
+	var dummyRound2 = &round{id: uuid.New(), trigger: TriggerSourceManual, scheduled: time.Now()}
+	var dummySession1 = &session{id: uuid.New(), index: 1, reruns: rand.IntN(100), round: dummyRound1}
+	var dummySession2 = &session{id: uuid.New(), index: 0, reruns: rand.IntN(100), round: dummyRound2}
+	var dummySession3 = &session{id: uuid.New(), index: 0, reruns: rand.IntN(100), round: dummyRound1}
+	var dummyApplication = &application{
+		name:         "some name",
+		version:      "some version",
+		started:      true,
+		paused:       true,
+		breakerState: BreakerStateHalfOpen,
+		nextRun:      dummyNextRun,
+		rounds: map[uuid.UUID]*round{
+			dummyRound1.id: dummyRound1,
+			dummyRound2.id: dummyRound2,
+		},
+		inflight: map[uuid.UUID]*session{
+			dummySession1.id: dummySession1,
+			dummySession2.id: dummySession2,
+			dummySession3.id: dummySession3,
+		},
+		jobs: []*application{
+			{name: "some job"},
+			{name: "other job"},
+		},
+	}
+
+	// SUT + act
+	var result = getAdminStatus(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Equal(t, adminStatus{
+		Name:              "some name",
+		Version:           "some version",
+		Running:           true,
+		Paused:            true,
+		BreakerState:      BreakerStateHalfOpen,
+		NextScheduledTime: &dummyNextRun,
+		RunningRounds:     2,
+		Instances: []adminInstance{
+			{
+				SessionID:     dummySession3.id,
+				RoundID:       dummyRound1.id,
+				Index:         0,
+				Reruns:        dummySession3.reruns,
+				Trigger:       TriggerSourceScheduled,
+				ScheduledTime: dummyRound1.scheduled,
+			},
+			{
+				SessionID:     dummySession1.id,
+				RoundID:       dummyRound1.id,
+				Index:         1,
+				Reruns:        dummySession1.reruns,
+				Trigger:       TriggerSourceScheduled,
+				ScheduledTime: dummyRound1.scheduled,
+			},
+			{
+				SessionID:     dummySession2.id,
+				RoundID:       dummyRound2.id,
+				Index:         0,
+				Reruns:        dummySession2.reruns,
+				Trigger:       TriggerSourceManual,
+				ScheduledTime: dummyRound2.scheduled,
+			},
+		},
+		Jobs: []string{"some job", "other job"},
+	}, result)
+}
+
+func TestWriteAdminJSON_MarshalError(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyValue = adminMessage{Message: "some message"}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(json.Marshal).Expects(dummyValue).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	writeAdminJSON(
+		dummyRecorder,
+		http.StatusOK,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, dummyRecorder.Code)
+	assert.Equal(t, "some error\n", dummyRecorder.Body.String())
+}
+
+func TestWriteAdminJSON_Success(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+
+	// SUT + act
+	writeAdminJSON(
+		dummyRecorder,
+		http.StatusAccepted,
+		adminMessage{Message: "some message"},
+	)
+
+	// assert
+	assert.Equal(t, http.StatusAccepted, dummyRecorder.Code)
+	assert.Equal(t, "application/json", dummyRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, `{"message":"some message"}`, dummyRecorder.Body.String())
+}
+
+func TestAuthorizeAdmin_NoToken(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status", nil)
+	var handled = false
+
+	// SUT
+	var sut = authorizeAdmin(
+		"",
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			handled = true
+		},
+	)
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.True(t, handled)
+}
+
+func TestAuthorizeAdmin_Unauthorized(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status", nil)
+	dummyRequest.Header.Set("Authorization", "Bearer other token")
+	var handled = false
+
+	// SUT
+	var sut = authorizeAdmin(
+		"some token",
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			handled = true
+		},
+	)
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.False(t, handled)
+	assert.Equal(t, http.StatusUnauthorized, dummyRecorder.Code)
+	assert.Equal(t, "Bearer", dummyRecorder.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, `{"message":"Unauthorized"}`, dummyRecorder.Body.String())
+}
+
+func TestAuthorizeAdmin_Authorized(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status", nil)
+	dummyRequest.Header.Set("Authorization", "Bearer some token")
+	var handled = false
+
+	// SUT
+	var sut = authorizeAdmin(
+		"some token",
+		func(responseWriter http.ResponseWriter, request *http.Request) {
+			handled = true
+		},
+	)
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.True(t, handled)
+}
+
+func TestResolveAdminTarget_Application(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status", nil)
+
+	// SUT + act
+	var result, found = resolveAdminTarget(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyApplication, result)
+	assert.True(t, found)
+}
+
+func TestResolveAdminTarget_JobNotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{{name: "some job"}},
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status?job=other+job", nil)
+
+	// SUT + act
+	var result, found = resolveAdminTarget(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.False(t, found)
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Job [other job] not found"}`, dummyRecorder.Body.String())
+}
+
+func TestResolveAdminTarget_JobFound(t *testing.T) {
+	// arrange
+	var dummyJob = &application{name: "some job"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyJob},
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status?job=some+job", nil)
+
+	// SUT + act
+	var result, found = resolveAdminTarget(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyJob, result)
+	assert.True(t, found)
+}
+
+func TestResolveAdminControlTarget_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{{name: "some job"}},
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/pause?job=other+job", nil)
+
+	// SUT + act
+	var result, found = resolveAdminControlTarget(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.False(t, found)
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestResolveAdminControlTarget_Runner(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{{name: "some job"}},
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/pause", nil)
+
+	// SUT + act
+	var result, found = resolveAdminControlTarget(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.False(t, found)
+	assert.Equal(t, http.StatusBadRequest, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Parameter [job] is required to control runner [some name]"}`, dummyRecorder.Body.String())
+}
+
+func TestResolveAdminControlTarget_Application(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/pause", nil)
+
+	// SUT + act
+	var result, found = resolveAdminControlTarget(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyApplication, result)
+	assert.True(t, found)
+}
+
+func TestServeHealthz(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+
+	// SUT + act
+	serveHealthz(
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"OK"}`, dummyRecorder.Body.String())
+}
+
+func TestServeReadyz_NotRunning(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	// SUT + act
+	serveReadyz(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusServiceUnavailable, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Not ready"}`, dummyRecorder.Body.String())
+}
+
+func TestServeReadyz_Halted(t *testing.T) {
+	// arrange
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	dummyHalt()
+	var dummyApplication = &application{
+		started:    true,
+		scheduling: dummyScheduling,
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	// SUT + act
+	serveReadyz(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusServiceUnavailable, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Not ready"}`, dummyRecorder.Body.String())
+}
+
+func TestServeReadyz_Ready(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		started:    true,
+		scheduling: context.Background(),
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	// SUT + act
+	serveReadyz(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Ready"}`, dummyRecorder.Body.String())
+}
+
+func TestServeStatus_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status?job=some+job", nil)
+
+	// SUT + act
+	serveStatus(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestServeStatus_Found(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/status", nil)
+	var dummyStatus = adminStatus{
+		Name:         "some name",
+		BreakerState: BreakerStateOpen,
+		Instances:    []adminInstance{},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getAdminStatus).Expects(dummyApplication).Returns(dummyStatus).Once()
+
+	// SUT + act
+	serveStatus(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.JSONEq(t, `{"name":"some name","version":"","running":false,"paused":false,"breakerState":"Open","runningRounds":0,"instances":[]}`, dummyRecorder.Body.String())
+}
+
+func TestServeErrors_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/errors?job=some+job", nil)
+
+	// SUT + act
+	serveErrors(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestServeErrors_All(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+	var dummyRunError = newRunError(nil, 1, 2, RunPhaseAction, errors.New("some error"))
+	dummyApplication.errors.push(dummyRunError)
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/errors", nil)
+
+	// SUT + act
+	serveErrors(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	var result = []*RunError{}
+	assert.NoError(t, json.Unmarshal(dummyRecorder.Body.Bytes(), &result))
+	assert.Len(t, result, 1)
+	assert.Equal(t, RunPhaseAction, result[0].Phase)
+	assert.Equal(t, "some error", result[0].Err.Error())
+}
+
+func TestServeErrors_InvalidSince(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/errors?since=some+time", nil)
+
+	// SUT + act
+	serveErrors(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, dummyRecorder.Code)
+	assert.Contains(t, dummyRecorder.Body.String(), "Invalid since [some time]")
+}
+
+func TestServeErrors_Since(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+	var dummyOldError = newRunError(nil, 1, 0, RunPhaseAction, errors.New("old error"))
+	dummyOldError.Timestamp = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyNewError = newRunError(nil, 2, 0, RunPhaseAction, errors.New("new error"))
+	dummyNewError.Timestamp = time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	dummyApplication.errors.push(dummyOldError)
+	dummyApplication.errors.push(dummyNewError)
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/errors?since=2030-01-01T12:00:00Z", nil)
+
+	// SUT + act
+	serveErrors(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	var result = []*RunError{}
+	assert.NoError(t, json.Unmarshal(dummyRecorder.Body.Bytes(), &result))
+	assert.Len(t, result, 1)
+	assert.Equal(t, "new error", result[0].Err.Error())
+}
+
+func TestServeRuns_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/runs?job=some+job", nil)
+
+	// SUT + act
+	serveRuns(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestServeRuns_Found(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		history: newRoundHistory(10),
+	}
+	var dummyRecord = &RoundRecord{ID: uuid.New(), Status: RoundStatusCompleted}
+	dummyApplication.history.add(dummyRecord)
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/runs", nil)
+
+	// SUT + act
+	serveRuns(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	var result = []*RoundRecord{}
+	assert.NoError(t, json.Unmarshal(dummyRecorder.Body.Bytes(), &result))
+	assert.Len(t, result, 1)
+	assert.Equal(t, dummyRecord.ID, result[0].ID)
+}
+
+func TestServeTrigger_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/trigger?job=some+job", nil)
+
+	// SUT + act
+	serveTrigger(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestServeTrigger_Error(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/trigger?reason=some+reason", nil)
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(triggerRound).Expects(dummyApplication, "some reason").Returns(dummyError).Once()
+
+	// SUT + act
+	serveTrigger(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusConflict, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"some error"}`, dummyRecorder.Body.String())
+}
+
+func TestServeTrigger_DefaultReason(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/trigger", nil)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(triggerRound).Expects(dummyApplication, "Triggered through admin server").Returns(nil).Once()
+
+	// SUT + act
+	serveTrigger(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusAccepted, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Triggered"}`, dummyRecorder.Body.String())
+}
+
+func TestServePause_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/pause?job=some+job", nil)
+
+	// SUT + act
+	servePause(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestServePause_Paused(t *testing.T) {
+	// arrange
+	var dummyJob = &application{name: "some job"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyJob},
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/pause?job=some+job", nil)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(pauseApplication).Expects(dummyJob).Returns().Once()
+
+	// SUT + act
+	servePause(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Paused"}`, dummyRecorder.Body.String())
+}
+
+func TestServeResume_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/resume?job=some+job", nil)
+
+	// SUT + act
+	serveResume(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, dummyRecorder.Code)
+}
+
+func TestServeResume_Resumed(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/resume", nil)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resumeApplication).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	serveResume(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Resumed"}`, dummyRecorder.Body.String())
+}
+
+func TestServeStop_NotRunning(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/stop", nil)
+
+	// SUT + act
+	serveStop(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusConflict, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Runner [some name] is not running"}`, dummyRecorder.Body.String())
+}
+
+func TestServeStop_Stopping(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          "some name",
+		started:       true,
+		customization: dummyCustomization,
+	}
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodPost, "/stop", nil)
+	var dummyTimeout = time.Duration(rand.IntN(100)) * time.Second
+	var stopped = make(chan struct{})
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).GracefulTimeout).Expects(dummyCustomization).Returns(dummyTimeout).Once()
+	m.Mock(stopGracefully).Expects(dummyApplication, dummyTimeout).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() {
+			close(stopped)
+		}),
+	).Once()
+
+	// SUT + act
+	serveStop(
+		dummyApplication,
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	<-stopped
+	assert.Equal(t, http.StatusAccepted, dummyRecorder.Code)
+	assert.Equal(t, `{"message":"Stopping"}`, dummyRecorder.Body.String())
+}
+
+func TestNewAdminHandler_Routes(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRoutes = map[string]func(*application, http.ResponseWriter, *http.Request){
+		"GET /readyz":   serveReadyz,
+		"GET /status":   serveStatus,
+		"GET /errors":   serveErrors,
+		"GET /runs":     serveRuns,
+		"POST /trigger": serveTrigger,
+		"POST /pause":   servePause,
+		"POST /resume":  serveResume,
+		"POST /stop":    serveStop,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	for _, serve := range dummyRoutes {
+		m.Mock(serve).Expects(dummyApplication, gomocker.Anything(), gomocker.Anything()).Returns().Once()
+	}
+	m.Mock(serveHealthz).Expects(gomocker.Anything(), gomocker.Anything()).Returns().Once()
+	m.Mock(serveMetrics).Expects(gomocker.Anything(), gomocker.Anything()).Returns().Once()
+
+	// SUT
+	var sut = newAdminHandler(
+		dummyApplication,
+		"",
+	)
+
+	// act
+	for route := range dummyRoutes {
+		var method, path, _ = strings.Cut(route, " ")
+		sut.ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(method, path, nil),
+		)
+	}
+	sut.ServeHTTP(
+		httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/healthz", nil),
+	)
+	sut.ServeHTTP(
+		httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/metrics", nil),
+	)
+}
+
+func TestNewAdminHandler_Token(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+
+	// SUT
+	var sut = newAdminHandler(
+		dummyApplication,
+		"some token",
+	)
+
+	// act
+	var healthzRecorder = httptest.NewRecorder()
+	sut.ServeHTTP(
+		healthzRecorder,
+		httptest.NewRequest(http.MethodGet, "/healthz", nil),
+	)
+	var readyzRecorder = httptest.NewRecorder()
+	sut.ServeHTTP(
+		readyzRecorder,
+		httptest.NewRequest(http.MethodGet, "/readyz", nil),
+	)
+	var statusRecorder = httptest.NewRecorder()
+	sut.ServeHTTP(
+		statusRecorder,
+		httptest.NewRequest(http.MethodGet, "/status", nil),
+	)
+	var methodRecorder = httptest.NewRecorder()
+	sut.ServeHTTP(
+		methodRecorder,
+		httptest.NewRequest(http.MethodGet, "/trigger", nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, healthzRecorder.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, readyzRecorder.Code)
+	assert.Equal(t, http.StatusUnauthorized, statusRecorder.Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, methodRecorder.Code)
+}
+
+func TestNewAdminHandler_Runner(t *testing.T) {
+	// arrange
+	var dummyRunner = NewRunner("some runner", "some version", &DefaultCustomization{})
+	assert.NoError(t, dummyRunner.Register("some job", 1, nil, OverlapPolicyAllow, &DefaultCustomization{}))
+	var dummyJob, _ = dummyRunner.Job("some job")
+	var dummyHost = dummyRunner.(*runner).app
+
+	// SUT
+	var sut = newAdminHandler(dummyHost, "")
+
+	// act
+	var serve = func(target string) *httptest.ResponseRecorder {
+		var recorder = httptest.NewRecorder()
+		sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, nil))
+		return recorder
+	}
+	var triggerResult = serve("/trigger")
+	var pauseResult = serve("/pause")
+	var pausedAfterRunnerPause = dummyJob.IsPaused()
+	var jobPauseResult = serve("/pause?job=some+job")
+	var pausedAfterJobPause = dummyJob.IsPaused()
+	var resumeResult = serve("/resume")
+	var jobResumeResult = serve("/resume?job=some+job")
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, triggerResult.Code)
+	assert.Equal(t, http.StatusBadRequest, pauseResult.Code)
+	assert.Equal(t, http.StatusBadRequest, resumeResult.Code)
+	assert.Equal(t, `{"message":"Parameter [job] is required to control runner [some runner]"}`, pauseResult.Body.String())
+	assert.Empty(t, dummyHost.History())
+	assert.False(t, dummyHost.IsPaused())
+	assert.False(t, pausedAfterRunnerPause)
+	assert.Equal(t, http.StatusOK, jobPauseResult.Code)
+	assert.True(t, pausedAfterJobPause)
+	assert.Equal(t, http.StatusOK, jobResumeResult.Code)
+	assert.False(t, dummyJob.IsPaused())
+}
+
+func TestServeAdmin_Closed(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyServer = &http.Server{}
+	var dummyListener, _ = net.Listen("tcp", "127.0.0.1:0")
+	dummyServer.Close()
+
+	// SUT + act
+	serveAdmin(
+		dummyApplication,
+		dummyServer,
+		dummyListener,
+	)
+}
+
+func TestServeAdmin_Error(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{session: dummySession}
+	var dummyServer = &http.Server{}
+	var dummyListener, _ = net.Listen("tcp", "127.0.0.1:0")
+	dummyListener.Close()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "admin", "serveAdmin",
+		"Admin server terminated unexpectedly. Error: %+v", gomocker.Anything()).Returns().Once()
+
+	// SUT + act
+	serveAdmin(
+		dummyApplication,
+		dummyServer,
+		dummyListener,
+	)
+}
+
+func TestStartAdminServer_NoAddress(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{customization: dummyCustomization}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).AdminServer).Expects(dummyCustomization).Returns(AdminServer{}).Once()
+
+	// SUT + act
+	startAdminServer(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Nil(t, dummyApplication.admin)
+}
+
+func TestStartAdminServer_ListenError(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		session:       dummySession,
+		customization: dummyCustomization,
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).AdminServer).Expects(dummyCustomization).Returns(AdminServer{Address: "some address"}).Once()
+	m.Mock(net.Listen).Expects("tcp", "some address").Returns(nil, dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "admin", "startAdminServer",
+		"Failed to start admin server on [%v] address [%v]. Error: %+v", "tcp", "some address", dummyError).Returns().Once()
+	m.Mock(recordError).Expects(dummyApplication, gomocker.Matches(func(value any) bool {
+		var runError = value.(*RunError)
+		return runError.Phase == RunPhaseAdmin && runError.Err == dummyError
+	})).Returns().Once()
+
+	// SUT + act
+	startAdminServer(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Nil(t, dummyApplication.admin)
+}
+
+func TestStartAdminServer_Unix(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:          "some name",
+		session:       dummySession,
+		customization: dummyCustomization,
+		rounds:        map[uuid.UUID]*round{},
+		inflight:      map[uuid.UUID]*session{},
+	}
+	var dummyPath = filepath.Join(t.TempDir(), "admin.sock")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).AdminServer).Expects(dummyCustomization).Returns(AdminServer{Network: "unix", Address: dummyPath, Token: "some token"}).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "admin", "startAdminServer",
+		"Admin server listening on [%v] address [%v]", "unix", gomocker.Anything()).Returns().Once()
+	m.Mock(logAppRoot).Expects(dummySession, "admin", "stopAdminServer",
+		"Admin server closed").Returns().Once()
+
+	// SUT + act
+	startAdminServer(
+		dummyApplication,
+	)
+	var client = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+				return net.Dial("unix", dummyPath)
+			},
+		},
+	}
+	var request, _ = http.NewRequest(http.MethodGet, "http://admin/status", nil)
+	request.Header.Set("Authorization", "Bearer some token")
+	var response, responseError = client.Do(request)
+	stopAdminServer(
+		dummyApplication,
+	)
+
+	// assert
+	assert.NoError(t, responseError)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var status adminStatus
+	assert.NoError(t, json.NewDecoder(response.Body).Decode(&status))
+	response.Body.Close()
+	assert.Equal(t, "some name", status.Name)
+	assert.Nil(t, dummyApplication.admin)
+}
+
+func TestStopAdminServer_NotStarted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+
+	// SUT + act
+	stopAdminServer(
+		dummyApplication,
+	)
+}
+
+func TestStopAdminServer_CloseError(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyServer = &http.Server{}
+	var dummyApplication = &application{
+		session: dummySession,
+		admin:   dummyServer,
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*http.Server).Close).Expects(dummyServer).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "admin", "stopAdminServer",
+		"Failed to close admin server. Error: %+v", dummyError).Returns().Once()
+
+	// SUT + act
+	stopAdminServer(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Nil(t, dummyApplication.admin)
+}
@@ -1,6 +1,9 @@
 package jobrunner
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,42 +15,125 @@ import (
 type Application interface {
 	// Start starts the job runner according to given specifications for number of instances (in parallel) and schedule frequency defined in application
 	Start()
+	// StartContext starts the job runner the same way as Start does, but bound to the given context; cancelling the context stops the job runner as if Stop was called
+	StartContext(ctx context.Context)
+	// StartAsync starts the job runner the same way as Start does without blocking, and returns a handle to supervise its execution
+	StartAsync() RunHandle
 	// IsRunning returns true if the job has been successfully started and is currently running
 	IsRunning() bool
-	// LastErrors returns the list of errors occurred during the execution of job instances up until now
+	// LastErrors returns the list of retained errors occurred during the execution of the job runner up until now, each being a *RunError
 	LastErrors() []error
-	// Stop interrupts the job runner hosting, causing the job runner to forcefully shutdown
+	// RunErrors returns the retained run error records, from the oldest to the latest
+	RunErrors() []*RunError
+	// ErrorsSince returns the retained run error records occurred at or after the given time
+	ErrorsSince(since time.Time) []*RunError
+	// ErrorsForInstance returns the retained run error records occurred during the execution of the instance with the given index
+	ErrorsForInstance(index int) []*RunError
+	// History returns the retained records of completed rounds with their instance outcomes, from the oldest to the latest
+	History() []*RoundRecord
+	// RoundCapStats returns the snapshot of the running rounds under the customized cap of concurrent rounds, along with how often the cap was hit
+	RoundCapStats() RoundCapStats
+	// BreakerState returns the current state of the circuit breaker guarding the scheduled rounds, which is always closed unless a circuit breaker is customized
+	BreakerState() BreakerState
+	// Stop interrupts the job runner hosting, causing the job runner to forcefully shutdown and the contexts of all running sessions to be cancelled
 	Stop()
+	// StopGracefully stops scheduling new rounds and waits for running instances to complete up to the given timeout, after which the remaining instances are cancelled and reported in LastErrors
+	StopGracefully(timeout time.Duration)
+	// Pause temporarily stops the scheduled rounds from being executed without tearing down the application; rounds becoming due while paused are skipped or queued according to the customized pause policy
+	Pause()
+	// Resume continues the scheduled rounds execution after a pause, executing the queued rounds if any
+	Resume()
+	// IsPaused returns true if the application is currently paused
+	IsPaused() bool
+	// TriggerNow executes a round of instances immediately outside of the schedule, e.g. for a backfill, respecting the overlap policy; returns error if the application is not running or the round is skipped or coalesced
+	TriggerNow(reason string) error
 }
 
 type application struct {
-	name          string
-	version       string
-	instances     int
-	reruns        []int32
-	schedule      Schedule
-	overlap       bool
-	session       *session
-	customization Customization
-	shutdown      chan bool
-	started       bool
-	lastErrors    []error
-	waits         sync.WaitGroup
+	name            string
+	version         string
+	instances       int
+	reruns          []int
+	schedule        Schedule
+	overlap         OverlapPolicy
+	session         *session
+	customization   Customization
+	ctx             context.Context
+	cancel          context.CancelFunc
+	scheduling      context.Context
+	halt            context.CancelFunc
+	shutdown        chan bool
+	terminated      chan struct{}
+	started         bool
+	errors          *errorRing
+	history         *roundHistory
+	store           HistoryStore
+	exporter        SpanExporter
+	clients         *httpClients
+	host            *application
+	jobs            []*application
+	upstreams       []string
+	downstreams     []*application
+	upstreamRecords map[string]*RoundRecord
+	arrivals        []map[string]*RoundRecord
+	arrived         chan struct{}
+	waits           sync.WaitGroup
+	rounds          map[uuid.UUID]*round
+	pending         *round
+	maxRounds       int
+	overflow        OverflowPolicy
+	overflowed      []*round
+	capStats        RoundCapStats
+	workQueue       bool
+	retry           RetryPolicy
+	actionTimeout   time.Duration
+	stages          []Stage
+	locking         LockPolicy
+	catchUp         CatchUpPolicy
+	breaker         CircuitBreaker
+	breakerState    BreakerState
+	failures        int
+	openedAt        time.Time
+	probe           uuid.UUID
+	paused          bool
+	queued          []time.Time
+	inflight        map[uuid.UUID]*session
+	nextRun         time.Time
+	admin           *http.Server
+	lock            sync.Mutex
 }
 
 // NewApplication creates a new application for job runner hosting
 //
-//	instances marks how many action functions to be executed in parallel at once for a single scheduled execution
+//	instances marks how many action functions to be executed in parallel at once for a single scheduled execution, unless customized per round through InstanceCount
 //	schedule is a CRON schedule managing when the action functions should be executed until stop signal is given
-//	overlap marks a new execution should be executed or not when a previous execution has not yet completed
+//	overlap marks how a new execution is handled when previous executions have not yet completed, i.e. skipped, executed concurrently, queued or replacing the previous ones
 func NewApplication(
 	name string,
 	version string,
 	instances int,
 	schedule Schedule,
-	overlap bool,
+	overlap OverlapPolicy,
 	customization Customization,
 ) Application {
+	return newApplication(
+		name,
+		version,
+		instances,
+		schedule,
+		overlap,
+		customization,
+	)
+}
+
+func newApplication(
+	name string,
+	version string,
+	instances int,
+	schedule Schedule,
+	overlap OverlapPolicy,
+	customization Customization,
+) *application {
 	if isInterfaceValueNil(customization) {
 		customization = customizationDefault
 	}
@@ -55,7 +141,7 @@ func NewApplication(
 		name:      name,
 		version:   version,
 		instances: instances,
-		reruns:    make([]int32, instances),
+		reruns:    make([]int, instances),
 		schedule:  schedule,
 		overlap:   overlap,
 		session: &session{
@@ -67,8 +153,11 @@ func NewApplication(
 		customization: customization,
 		shutdown:      make(chan bool),
 		started:       false,
-		lastErrors:    []error{},
+		errors:        newErrorRing(customization.ErrorRetention()),
+		history:       newRoundHistory(customization.HistoryRetention()),
 		waits:         sync.WaitGroup{},
+		rounds:        map[uuid.UUID]*round{},
+		inflight:      map[uuid.UUID]*session{},
 	}
 	return application
 }
@@ -76,37 +165,137 @@ func NewApplication(
 func (app *application) Start() {
 	startApplication(
 		app,
+		context.Background(),
+	)
+}
+
+func (app *application) StartContext(ctx context.Context) {
+	startApplication(
+		app,
+		ctx,
 	)
 }
 
 func (app *application) IsRunning() bool {
+	app.lock.Lock()
+	defer app.lock.Unlock()
 	return app.started
 }
 
 func (app *application) LastErrors() []error {
-	return app.lastErrors
+	var lastErrors = []error{}
+	for _, runError := range app.RunErrors() {
+		lastErrors = append(
+			lastErrors,
+			runError,
+		)
+	}
+	return lastErrors
+}
+
+func (app *application) RunErrors() []*RunError {
+	return app.errors.filter(
+		func(runError *RunError) bool {
+			return true
+		},
+	)
+}
+
+func (app *application) ErrorsSince(since time.Time) []*RunError {
+	return app.errors.filter(
+		func(runError *RunError) bool {
+			return !runError.Timestamp.Before(since)
+		},
+	)
+}
+
+func (app *application) ErrorsForInstance(index int) []*RunError {
+	return app.errors.filter(
+		func(runError *RunError) bool {
+			return runError.Index == index
+		},
+	)
+}
+
+func (app *application) History() []*RoundRecord {
+	return app.history.list()
+}
+
+func (app *application) RoundCapStats() RoundCapStats {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	var stats = app.capStats
+	if app.maxRounds > 0 {
+		stats.Limit = app.maxRounds
+	}
+	stats.Running = len(app.rounds)
+	stats.Waiting = len(app.overflowed)
+	return stats
+}
+
+func (app *application) BreakerState() BreakerState {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	return app.breakerState
 }
 
 func (app *application) Stop() {
-	if !app.started {
+	if !app.IsRunning() {
 		return
 	}
-	app.shutdown <- true
+	app.cancel()
 }
 
-func startApplication(app *application) {
-	if app.started {
+func (app *application) StopGracefully(timeout time.Duration) {
+	if !app.IsRunning() {
+		return
+	}
+	stopGracefully(
+		app,
+		timeout,
+	)
+}
+
+func (app *application) Pause() {
+	pauseApplication(
+		app,
+	)
+}
+
+func (app *application) Resume() {
+	resumeApplication(
+		app,
+	)
+}
+
+func (app *application) IsPaused() bool {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	return app.paused
+}
+
+func (app *application) TriggerNow(reason string) error {
+	return triggerRound(
+		app,
+		reason,
+	)
+}
+
+func startApplication(app *application, ctx context.Context) {
+	if app.IsRunning() ||
+		app.host != nil {
 		return
 	}
 	if !preBootstraping(app) {
 		return
 	}
 	bootstrap(app)
+	defer stopAdminServer(app)
 	if !postBootstraping(app) {
 		return
 	}
 	defer endApplication(app)
-	beginApplication(app)
+	beginApplication(app, ctx)
 }
 
 func preBootstraping(app *application) bool {
@@ -119,9 +308,15 @@ func preBootstraping(app *application) bool {
 			"Failed to execute customization.PreBootstrap. Error: %+v",
 			preBootstrapError,
 		)
-		app.lastErrors = append(
-			app.lastErrors,
-			preBootstrapError,
+		recordError(
+			app,
+			newRunError(
+				nil,
+				-1,
+				0,
+				RunPhasePreBootstrap,
+				preBootstrapError,
+			),
 		)
 		return false
 	}
@@ -135,12 +330,19 @@ func preBootstraping(app *application) bool {
 }
 
 func bootstrap(app *application) {
-	initializeHTTPClients(
+	app.clients = initializeHTTPClients(
 		app.customization.DefaultTimeout(),
 		app.customization.SkipServerCertVerification(),
 		app.customization.ClientCert(),
 		app.customization.RoundTripper,
 	)
+	app.session.clients = app.clients
+	configureApplication(
+		app,
+	)
+	startAdminServer(
+		app,
+	)
 	logAppRoot(
 		app.session,
 		"application",
@@ -149,6 +351,21 @@ func bootstrap(app *application) {
 	)
 }
 
+// configureApplication loads the execution settings of the application from its customization, e.g. the cap of concurrent rounds, the retry policy, etc.
+func configureApplication(app *application) {
+	app.store = app.customization.HistoryStore()
+	app.exporter = app.customization.SpanExporter()
+	app.maxRounds = app.customization.MaxConcurrentRounds()
+	app.overflow = app.customization.OverflowPolicy()
+	app.workQueue = app.customization.WorkQueue()
+	app.retry = app.customization.RetryPolicy()
+	app.actionTimeout = app.customization.ActionTimeout()
+	app.stages = app.customization.Stages()
+	app.breaker = app.customization.CircuitBreaker()
+	app.locking = app.customization.LockPolicy()
+	app.catchUp = app.customization.CatchUpPolicy()
+}
+
 func postBootstraping(app *application) bool {
 	var postBootstrapError = app.customization.PostBootstrap()
 	if postBootstrapError != nil {
@@ -159,9 +376,15 @@ func postBootstraping(app *application) bool {
 			"Failed to execute customization.PostBootstrap. Error: %+v",
 			postBootstrapError,
 		)
-		app.lastErrors = append(
-			app.lastErrors,
-			postBootstrapError,
+		recordError(
+			app,
+			newRunError(
+				nil,
+				-1,
+				0,
+				RunPhasePostBootstrap,
+				postBootstrapError,
+			),
 		)
 		return false
 	}
@@ -174,8 +397,20 @@ func postBootstraping(app *application) bool {
 	return true
 }
 
-func waitForNextRun(app *application) {
+// waitForNextRun waits till the next scheduled time, and returns the scheduled time together with whether a round should be executed
+func waitForNextRun(app *application) (time.Time, bool) {
 	var timeNext = app.schedule.NextSchedule()
+	app.lock.Lock()
+	if timeNext == nil {
+		app.nextRun = time.Time{}
+	} else {
+		app.nextRun = *timeNext
+	}
+	app.lock.Unlock()
+	observeNextRun(
+		app,
+		timeNext,
+	)
 	if timeNext == nil {
 		logAppRoot(
 			app.session,
@@ -183,8 +418,7 @@ func waitForNextRun(app *application) {
 			"waitForNextRun",
 			"No next schedule available, terminating execution",
 		)
-		app.started = false
-		return
+		return time.Time{}, false
 	}
 	var waitDuration = timeNext.Sub(
 		time.Now(),
@@ -197,75 +431,659 @@ func waitForNextRun(app *application) {
 		*timeNext,
 		waitDuration,
 	)
-	<-time.After(
+	select {
+	case <-time.After(
 		waitDuration,
+	):
+	case <-app.scheduling.Done():
+	}
+	if app.scheduling.Err() != nil {
+		observeNextRun(
+			app,
+			nil,
+		)
+		logAppRoot(
+			app.session,
+			"application",
+			"waitForNextRun",
+			"Scheduling halted, terminating execution",
+		)
+		return time.Time{}, false
+	}
+	return *timeNext, true
+}
+
+// resolveInstanceCount consults the customization for how many instances to execute in the given round, falling back to the instances given to the application
+func resolveInstanceCount(app *application, round *round) int {
+	var count = app.customization.InstanceCount(
+		newRoundInfo(round),
 	)
+	if count <= 0 {
+		return app.instances
+	}
+	return count
+}
+
+// nextReruns increments and returns the rerun count of the instance with the given index, growing the bookkeeping for instances never executed before
+func nextReruns(app *application, index int) int {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	for len(app.reruns) <= index {
+		app.reruns = append(
+			app.reruns,
+			0,
+		)
+	}
+	app.reruns[index]++
+	return app.reruns[index]
 }
 
-func runInstances(app *application) {
+// partitionRound consults the customization for the payloads assigned to the shards of the given round, and returns false if the partitioning failed
+func partitionRound(app *application, round *round) (partitioned bool) {
+	var session = initiateSession(
+		app,
+		round,
+		-1,
+		0,
+	)
+	defer func() {
+		var recoverResult = recover()
+		if recoverResult == nil {
+			return
+		}
+		var recoverError = finalizeSession(
+			session,
+			nil,
+			recoverResult,
+		)
+		logAppRoot(
+			app.session,
+			"application",
+			"partitionRound",
+			"Failed to partition round [%v] into [%v] shard(s). Error: %+v",
+			round.id,
+			round.instances,
+			recoverError,
+		)
+		round.failed.Store(true)
+		recordError(
+			app,
+			newRunError(
+				round,
+				-1,
+				0,
+				RunPhasePanic,
+				recoverError,
+			),
+		)
+		partitioned = false
+	}()
+	round.payloads = app.customization.Partition(
+		session,
+		round.instances,
+	)
+	return true
+}
+
+func runInstances(app *application, round *round) {
+	defer round.cancel()
+	round.span = startRoundSpan(
+		app,
+		round,
+	)
+	var release, locked = lockRound(
+		app,
+		round,
+	)
+	if !locked {
+		round.lockedOut.Store(true)
+		var record = newDroppedRoundRecord(
+			round,
+			RoundStatusLocked,
+		)
+		recordRound(
+			app,
+			record,
+		)
+		endRoundSpan(
+			app,
+			round,
+			record,
+		)
+		return
+	}
+	defer release()
+	var startTime = time.Now().UTC()
+	round.instances = resolveInstanceCount(
+		app,
+		round,
+	)
+	if app.workQueue {
+		round.work = produceWork(
+			app,
+			round,
+		)
+		if round.work == nil {
+			round.instances = 0
+		}
+	} else if !partitionRound(
+		app,
+		round,
+	) {
+		round.instances = 0
+	}
+	var records = make([]*InstanceRecord, round.instances)
+	var lockedInstances atomic.Int32
 	var waitGroup sync.WaitGroup
-	for id := 0; id < app.instances; id++ {
+	for id := 0; id < round.instances; id++ {
 		waitGroup.Add(1)
-		atomic.AddInt32(&app.reruns[id], 1)
 		go func(index int, reruns int) {
-			var sessionError = handleSession(
+			var record = handleLockedSession(
 				app,
+				round,
 				index,
 				reruns,
 			)
-			if sessionError != nil {
-				app.lastErrors = append(
-					app.lastErrors,
-					sessionError,
+			if record.Err != nil {
+				round.failed.Store(true)
+				recordError(
+					app,
+					record.Err,
 				)
+			} else if record.Outcome == InstanceOutcomeLocked {
+				lockedInstances.Add(1)
 			}
+			recordInstance(
+				app,
+				round,
+				record,
+			)
+			records[index] = record
 			waitGroup.Done()
-		}(id, int(app.reruns[id]))
+		}(id, nextReruns(app, id))
 	}
 	waitGroup.Wait()
-	if app.overlap {
-		app.waits.Done()
+	if round.instances > 0 &&
+		int(lockedInstances.Load()) == round.instances {
+		// all instances executed by other replicas
+		round.lockedOut.Store(true)
 	}
+	if round.ctx.Err() != nil {
+		// cut short by stopping the application before the round completes
+		round.cancelled.Store(true)
+	}
+	var record = newRoundRecord(
+		round,
+		startTime,
+		records,
+	)
+	recordRound(
+		app,
+		record,
+	)
+	endRoundSpan(
+		app,
+		round,
+		record,
+	)
 }
 
-func scheduleExecution(app *application) {
-	for {
-		waitForNextRun(
+// admitRound decides according to the overlap policy whether the incoming round could start right away, and returns the previous rounds to be cancelled if any; a round not admitted is either skipped, queued or coalesced into the queued one
+func admitRound(app *application, incoming *round) (bool, []*round) {
+	app.lock.Lock()
+	var running = len(app.rounds)
+	if running > 0 &&
+		app.overlap == OverlapPolicySkip {
+		app.lock.Unlock()
+		logAppRoot(
+			app.session,
+			"application",
+			"admitRound",
+			"Round [%v] skipped by overlap policy [%v] with [%v] round(s) still running",
+			incoming.id,
+			app.overlap,
+			running,
+		)
+		recordRound(
 			app,
+			newDroppedRoundRecord(
+				incoming,
+				RoundStatusSkipped,
+			),
 		)
-		if !app.started {
-			break
+		return false, nil
+	}
+	if running > 0 &&
+		app.overlap == OverlapPolicyQueueOne {
+		var pending = app.pending
+		if pending == nil {
+			app.pending = incoming
 		}
-		if app.overlap {
-			app.waits.Add(1)
-			go runInstances(
-				app,
+		app.lock.Unlock()
+		if pending == nil {
+			logAppRoot(
+				app.session,
+				"application",
+				"admitRound",
+				"Round [%v] queued by overlap policy [%v] with [%v] round(s) still running",
+				incoming.id,
+				app.overlap,
+				running,
 			)
-		} else {
-			runInstances(
-				app,
+			return false, nil
+		}
+		logAppRoot(
+			app.session,
+			"application",
+			"admitRound",
+			"Round [%v] coalesced into queued round [%v] by overlap policy [%v]",
+			incoming.id,
+			pending.id,
+			app.overlap,
+		)
+		recordRound(
+			app,
+			newDroppedRoundRecord(
+				incoming,
+				RoundStatusCoalesced,
+			),
+		)
+		return false, nil
+	}
+	if app.overlap == OverlapPolicyAllow &&
+		app.maxRounds > 0 &&
+		running >= app.maxRounds {
+		app.capStats.Hits++
+		if app.overflow == OverflowPolicyQueue {
+			app.capStats.Queued++
+			app.overflowed = append(
+				app.overflowed,
+				incoming,
+			)
+			var waiting = len(app.overflowed)
+			app.lock.Unlock()
+			logAppRoot(
+				app.session,
+				"application",
+				"admitRound",
+				"Round [%v] queued by overflow policy [%v] with [%v] round(s) running at the cap and [%v] round(s) waiting",
+				incoming.id,
+				app.overflow,
+				running,
+				waiting,
 			)
+			return false, nil
 		}
+		app.capStats.Skipped++
+		app.lock.Unlock()
+		logAppRoot(
+			app.session,
+			"application",
+			"admitRound",
+			"Round [%v] skipped by overflow policy [%v] with [%v] round(s) running at the cap",
+			incoming.id,
+			app.overflow,
+			running,
+		)
+		recordRound(
+			app,
+			newDroppedRoundRecord(
+				incoming,
+				RoundStatusSkipped,
+			),
+		)
+		return false, nil
 	}
-	if app.overlap {
-		app.waits.Wait()
+	var previous = registerRound(
+		app,
+		incoming,
+	)
+	app.lock.Unlock()
+	return true, previous
+}
+
+// registerRound registers the admitted round as running, and returns the previous rounds to be cancelled according to the overlap policy; the application lock must be held by the caller
+func registerRound(app *application, incoming *round) []*round {
+	var previous = []*round{}
+	if app.overlap == OverlapPolicyCancelPrevious {
+		for _, running := range app.rounds {
+			previous = append(
+				previous,
+				running,
+			)
+		}
 	}
+	incoming.ctx, incoming.cancel = context.WithCancel(
+		app.ctx,
+	)
+	app.rounds[incoming.id] = incoming
+	return previous
+}
+
+// releaseRound unregisters the completed round, and hands its slot over to the round waiting next if any, which is returned already registered along with the previous rounds to be cancelled
+func releaseRound(app *application, completed *round) (*round, []*round) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	delete(app.rounds, completed.id)
+	if app.scheduling.Err() != nil {
+		app.overflowed = nil
+		app.pending = nil
+		return nil, nil
+	}
+	var next *round
+	if len(app.overflowed) > 0 {
+		next = app.overflowed[0]
+		app.overflowed = app.overflowed[1:]
+	} else if len(app.rounds) == 0 {
+		next = app.pending
+		app.pending = nil
+	}
+	if next == nil {
+		return nil, nil
+	}
+	return next, registerRound(
+		app,
+		next,
+	)
+}
+
+func cancelRound(app *application, round *round) {
+	round.cancelled.Store(true)
+	round.cancel()
+	logAppRoot(
+		app.session,
+		"application",
+		"cancelRound",
+		"Round [%v] cancelled by overlap policy [%v] in favor of a new round",
+		round.id,
+		app.overlap,
+	)
+}
+
+func completeRound(app *application, round *round) {
+	defer app.waits.Done()
+	runInstances(
+		app,
+		round,
+	)
+	recordBreakerResult(
+		app,
+		round,
+	)
+	var next, previous = releaseRound(
+		app,
+		round,
+	)
+	if next != nil {
+		launchRound(
+			app,
+			next,
+			previous,
+		)
+	}
+}
+
+// launchRound cancels the given previous rounds and executes the admitted round in background, which is tracked for the application to wait for upon termination
+func launchRound(app *application, round *round, previous []*round) {
+	for _, running := range previous {
+		cancelRound(
+			app,
+			running,
+		)
+	}
+	app.waits.Add(1)
+	go completeRound(
+		app,
+		round,
+	)
+}
+
+// dispatchRound executes a round of instances in background if admitted by the overlap policy and the cap of concurrent rounds
+func dispatchRound(app *application, round *round) {
+	var admitted, previous = admitRound(
+		app,
+		round,
+	)
+	if !admitted {
+		return
+	}
+	launchRound(
+		app,
+		round,
+		previous,
+	)
+}
+
+func triggerRound(app *application, reason string) error {
+	if !app.IsRunning() ||
+		app.scheduling.Err() != nil {
+		return fmt.Errorf(
+			"Runner [%v] is not running, unable to trigger a round",
+			app.name,
+		)
+	}
+	logAppRoot(
+		app.session,
+		"application",
+		"triggerRound",
+		"Round triggered manually: %v",
+		reason,
+	)
+	var round = newRound(
+		TriggerSourceManual,
+		reason,
+		time.Now().UTC(),
+	)
+	var admitted, previous = admitRound(
+		app,
+		round,
+	)
+	if admitted {
+		launchRound(
+			app,
+			round,
+			previous,
+		)
+		return nil
+	}
+	app.lock.Lock()
+	var queued = isRoundAlive(
+		app,
+		round.id,
+	)
+	app.lock.Unlock()
+	if queued {
+		return nil
+	}
+	return fmt.Errorf(
+		"Runner [%v] dropped the triggered round [%v] due to overlap policy [%v] or overflow policy [%v]",
+		app.name,
+		round.id,
+		app.overlap,
+		app.overflow,
+	)
+}
+
+// holdPausedRound skips or queues the due round of the given scheduled time according to the pause policy, and returns true if the round is held back due to the application being paused; queued rounds keep their scheduled time to be replayed with upon resuming
+func holdPausedRound(app *application, scheduled time.Time) bool {
+	app.lock.Lock()
+	if !app.paused {
+		app.lock.Unlock()
+		return false
+	}
+	var pausePolicy = app.customization.PausePolicy()
+	if pausePolicy == PausePolicyQueue {
+		app.queued = append(
+			app.queued,
+			scheduled,
+		)
+	}
+	var queued = len(app.queued)
+	app.lock.Unlock()
+	logAppRoot(
+		app.session,
+		"application",
+		"holdPausedRound",
+		"Runner paused, round held back by policy [%v] with [%v] round(s) queued",
+		pausePolicy,
+		queued,
+	)
+	return true
+}
+
+func pauseApplication(app *application) {
+	app.lock.Lock()
+	if app.paused {
+		app.lock.Unlock()
+		return
+	}
+	app.paused = true
+	app.lock.Unlock()
+	logAppRoot(
+		app.session,
+		"application",
+		"pauseApplication",
+		"Runner paused",
+	)
+}
+
+func resumeApplication(app *application) {
+	app.lock.Lock()
+	if !app.paused {
+		app.lock.Unlock()
+		return
+	}
+	app.paused = false
+	var queued = app.queued
+	app.queued = nil
+	var replaying = len(queued) > 0 &&
+		app.scheduling.Err() == nil
+	if replaying {
+		// tracked under the lock, before scheduling could drop the queue and the application wait for its rounds
+		app.waits.Add(1)
+	}
+	app.lock.Unlock()
+	logAppRoot(
+		app.session,
+		"application",
+		"resumeApplication",
+		"Runner resumed with [%v] round(s) queued",
+		len(queued),
+	)
+	if !replaying {
+		return
+	}
+	defer app.waits.Done()
+	for _, scheduled := range queued {
+		if app.scheduling.Err() != nil {
+			return
+		}
+		var round = newRound(
+			TriggerSourceScheduled,
+			"",
+			scheduled,
+		)
+		if holdTrippedRound(
+			app,
+			round,
+		) {
+			continue
+		}
+		dispatchRound(
+			app,
+			round,
+		)
+	}
+}
+
+// dropPausedRounds discards the rounds queued while paused once scheduling stops
+func dropPausedRounds(app *application) {
+	app.lock.Lock()
+	var queued = len(app.queued)
+	app.queued = nil
+	app.lock.Unlock()
+	if queued == 0 {
+		return
+	}
+	logAppRoot(
+		app.session,
+		"application",
+		"dropPausedRounds",
+		"Scheduling stopped, [%v] round(s) queued while paused dropped",
+		queued,
+	)
+}
+
+func scheduleExecution(app *application) {
+	catchUpRounds(
+		app,
+	)
+	for {
+		var timeNext, proceed = waitForNextRun(
+			app,
+		)
+		if !proceed {
+			break
+		}
+		if holdPausedRound(
+			app,
+			timeNext,
+		) {
+			continue
+		}
+		var round = newRound(
+			TriggerSourceScheduled,
+			"",
+			timeNext,
+		)
+		if holdTrippedRound(
+			app,
+			round,
+		) {
+			continue
+		}
+		dispatchRound(
+			app,
+			round,
+		)
+	}
+	dropPausedRounds(
+		app,
+	)
 }
 
 func runApplication(app *application) {
-	if isInterfaceValueNil(app.schedule) {
-		runInstances(
+	var ctx = app.ctx
+	if app.jobs != nil {
+		runJobs(
+			app,
+		)
+	} else if app.arrived != nil {
+		awaitUpstreams(
 			app,
 		)
+	} else if isInterfaceValueNil(app.schedule) {
+		dispatchRound(
+			app,
+			newRound(
+				TriggerSourceScheduled,
+				"",
+				time.Now().UTC(),
+			),
+		)
 	} else {
 		scheduleExecution(
 			app,
 		)
 	}
-	app.shutdown <- true
+	app.waits.Wait()
+	select {
+	case app.shutdown <- true:
+	case <-ctx.Done():
+	}
 }
 
-func beginApplication(app *application) {
+func beginApplication(app *application, ctx context.Context) {
 	logAppRoot(
 		app.session,
 		"application",
@@ -274,16 +1092,118 @@ func beginApplication(app *application) {
 		app.name,
 		app.version,
 	)
+	app.ctx, app.cancel = context.WithCancel(
+		ctx,
+	)
+	app.scheduling, app.halt = context.WithCancel(
+		app.ctx,
+	)
+	if app.host != nil {
+		// a hosted job stops scheduling along with its hosting runner
+		context.AfterFunc(
+			app.host.scheduling,
+			app.halt,
+		)
+	}
+	app.terminated = make(chan struct{})
+	app.lock.Lock()
 	app.started = true
+	app.lock.Unlock()
+	go handleSignals(app, trapSignals(app))
 	go runApplication(app)
-	<-app.shutdown
+	select {
+	case <-app.shutdown:
+	case <-app.ctx.Done():
+	}
+	app.cancel()
+	app.lock.Lock()
 	app.started = false
+	app.lock.Unlock()
 	logAppRoot(
 		app.session,
 		"application",
 		"beginApplication",
 		"Runner terminated",
 	)
+	close(app.terminated)
+}
+
+func registerSession(app *application, session *session) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	app.inflight[session.id] = session
+	inflightInstances.add(
+		1,
+		app.name,
+	)
+}
+
+func unregisterSession(app *application, session *session) {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	delete(app.inflight, session.id)
+	inflightInstances.add(
+		-1,
+		app.name,
+	)
+}
+
+func terminateInstances(app *application) {
+	for _, job := range app.jobs {
+		terminateInstances(
+			job,
+		)
+	}
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	for _, session := range app.inflight {
+		var terminateError = fmt.Errorf(
+			"Instance [%v] (rerun [%v]) of session [%v] force-terminated after graceful shutdown timeout",
+			session.index,
+			session.reruns,
+			session.id,
+		)
+		logAppRoot(
+			app.session,
+			"application",
+			"terminateInstances",
+			"%v",
+			terminateError,
+		)
+		recordError(
+			app,
+			newRunError(
+				session.round,
+				session.index,
+				session.reruns,
+				RunPhaseTerminate,
+				terminateError,
+			),
+		)
+	}
+}
+
+func stopGracefully(app *application, timeout time.Duration) {
+	logAppRoot(
+		app.session,
+		"application",
+		"stopGracefully",
+		"Halting scheduling and waiting up to [%v] for running instances to complete",
+		timeout,
+	)
+	app.halt()
+	select {
+	case <-app.terminated:
+		return
+	case <-time.After(
+		timeout,
+	):
+	}
+	terminateInstances(
+		app,
+	)
+	app.cancel()
+	<-app.terminated
 }
 
 func endApplication(app *application) {
@@ -296,9 +1216,15 @@ func endApplication(app *application) {
 			"Failed to execute customization.AppClosing. Error: %+v",
 			appClosingError,
 		)
-		app.lastErrors = append(
-			app.lastErrors,
-			appClosingError,
+		recordError(
+			app,
+			newRunError(
+				nil,
+				-1,
+				0,
+				RunPhaseAppClosing,
+				appClosingError,
+			),
 		)
 	} else {
 		logAppRoot(
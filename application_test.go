@@ -1,10 +1,12 @@
 package jobrunner
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
 	"math/rand/v2"
+	"os"
 	"reflect"
 	"sync"
 	"testing"
@@ -31,9 +33,11 @@ func TestNewApplication_NilCustomization(t *testing.T) {
 	var dummyVersion = "some version"
 	var dummyInstances = rand.IntN(100)
 	var dummySchedule Schedule
-	var dummyOverlap = rand.IntN(100) > 50
+	var dummyOverlap = OverlapPolicy(rand.IntN(4))
 	var dummyCustomization Customization
 	var dummySessionID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	var dummyErrors = &errorRing{}
+	var dummyHistory = &roundHistory{}
 
 	// mock
 	var m = gomocker.NewMocker(t)
@@ -41,6 +45,8 @@ func TestNewApplication_NilCustomization(t *testing.T) {
 	// expect
 	m.Mock(isInterfaceValueNil).Expects(dummyCustomization).Returns(true).Once()
 	m.Mock(uuid.New).Expects().Returns(dummySessionID).Once()
+	m.Mock(newErrorRing).Expects(100).Returns(dummyErrors).Once()
+	m.Mock(newRoundHistory).Expects(100).Returns(dummyHistory).Once()
 
 	// SUT
 	var result = NewApplication(
@@ -69,6 +75,12 @@ func TestNewApplication_NilCustomization(t *testing.T) {
 	assert.Empty(t, value.session.attachment)
 	assert.Equal(t, customizationDefault, value.session.customization)
 	assert.Equal(t, customizationDefault, value.customization)
+	assert.Equal(t, dummyErrors, value.errors)
+	assert.Equal(t, dummyHistory, value.history)
+	assert.NotNil(t, value.rounds)
+	assert.Empty(t, value.rounds)
+	assert.NotNil(t, value.inflight)
+	assert.Empty(t, value.inflight)
 }
 
 func TestNewApplication_HasCustomization(t *testing.T) {
@@ -77,12 +89,15 @@ func TestNewApplication_HasCustomization(t *testing.T) {
 	var dummyVersion = "some version"
 	var dummyInstances = rand.IntN(100)
 	var dummySchedule Schedule
-	var dummyOverlap = rand.IntN(100) > 50
+	var dummyOverlap = OverlapPolicy(rand.IntN(4))
 	type customization struct {
 		Customization
 	}
 	var dummyCustomization = &customization{}
 	var dummySessionID = uuid.New()
+	var dummyRetention = rand.IntN(100)
+	var dummyErrors = &errorRing{}
+	var dummyHistory = &roundHistory{}
 
 	// mock
 	var m = gomocker.NewMocker(t)
@@ -90,6 +105,10 @@ func TestNewApplication_HasCustomization(t *testing.T) {
 	// expect
 	m.Mock(isInterfaceValueNil).Expects(dummyCustomization).Returns(false).Once()
 	m.Mock(uuid.New).Expects().Returns(dummySessionID).Once()
+	m.Mock((*customization).ErrorRetention).Expects(dummyCustomization).Returns(dummyRetention).Once()
+	m.Mock(newErrorRing).Expects(dummyRetention).Returns(dummyErrors).Once()
+	m.Mock((*customization).HistoryRetention).Expects(dummyCustomization).Returns(dummyRetention).Once()
+	m.Mock(newRoundHistory).Expects(dummyRetention).Returns(dummyHistory).Once()
 
 	// SUT
 	var result = NewApplication(
@@ -118,6 +137,12 @@ func TestNewApplication_HasCustomization(t *testing.T) {
 	assert.Empty(t, value.session.attachment)
 	assert.Equal(t, dummyCustomization, value.session.customization)
 	assert.Equal(t, dummyCustomization, value.customization)
+	assert.Equal(t, dummyErrors, value.errors)
+	assert.Equal(t, dummyHistory, value.history)
+	assert.NotNil(t, value.rounds)
+	assert.Empty(t, value.rounds)
+	assert.NotNil(t, value.inflight)
+	assert.Empty(t, value.inflight)
 }
 
 func TestApplication_Start(t *testing.T) {
@@ -130,12 +155,29 @@ func TestApplication_Start(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(startApplication).Expects(dummyApplication).Returns().Once()
+	m.Mock(startApplication).Expects(dummyApplication, context.Background()).Returns().Once()
 
 	// SUT + act
 	dummyApplication.Start()
 }
 
+func TestApplication_StartContext(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyContext = context.TODO()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startApplication).Expects(dummyApplication, dummyContext).Returns().Once()
+
+	// SUT + act
+	dummyApplication.StartContext(dummyContext)
+}
+
 func TestApplication_IsRunning(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
@@ -152,21 +194,176 @@ func TestApplication_IsRunning(t *testing.T) {
 
 func TestApplication_LastErrors(t *testing.T) {
 	// arrange
-	var dummyLastErrors = []error{
-		errors.New("some error 1"),
-		errors.New("some error 2"),
-		errors.New("some error 3"),
+	var dummyRunErrors = []*RunError{
+		{Err: errors.New("some error 1")},
+		{Err: errors.New("some error 2")},
 	}
 	var dummyApplication = &application{
-		name:       "some name",
-		lastErrors: dummyLastErrors,
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+
+	// stub
+	for _, runError := range dummyRunErrors {
+		dummyApplication.errors.push(runError)
 	}
 
 	// SUT + act
 	var result = dummyApplication.LastErrors()
 
 	// assert
-	assert.Equal(t, dummyApplication.lastErrors, result)
+	assert.Equal(t, []error{dummyRunErrors[0], dummyRunErrors[1]}, result)
+}
+
+func TestApplication_RunErrors(t *testing.T) {
+	// arrange
+	var dummyRunErrors = []*RunError{
+		{Err: errors.New("some error 1")},
+		{Err: errors.New("some error 2")},
+		{Err: errors.New("some error 3")},
+	}
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(2),
+	}
+
+	// stub
+	for _, runError := range dummyRunErrors {
+		dummyApplication.errors.push(runError)
+	}
+
+	// SUT + act
+	var result = dummyApplication.RunErrors()
+
+	// assert
+	assert.Equal(t, dummyRunErrors[1:], result)
+}
+
+func TestApplication_ErrorsSince(t *testing.T) {
+	// arrange
+	var dummyTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyRunErrors = []*RunError{
+		{Timestamp: dummyTime.Add(-time.Second)},
+		{Timestamp: dummyTime},
+		{Timestamp: dummyTime.Add(time.Second)},
+	}
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+
+	// stub
+	for _, runError := range dummyRunErrors {
+		dummyApplication.errors.push(runError)
+	}
+
+	// SUT + act
+	var result = dummyApplication.ErrorsSince(dummyTime)
+
+	// assert
+	assert.Equal(t, dummyRunErrors[1:], result)
+}
+
+func TestApplication_ErrorsForInstance(t *testing.T) {
+	// arrange
+	var dummyRunErrors = []*RunError{
+		{Index: 1},
+		{Index: 2},
+		{Index: 1},
+	}
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+
+	// stub
+	for _, runError := range dummyRunErrors {
+		dummyApplication.errors.push(runError)
+	}
+
+	// SUT + act
+	var result = dummyApplication.ErrorsForInstance(1)
+
+	// assert
+	assert.Equal(t, []*RunError{dummyRunErrors[0], dummyRunErrors[2]}, result)
+}
+
+func TestApplication_History(t *testing.T) {
+	// arrange
+	var dummyRecords = []*RoundRecord{
+		{ID: uuid.New()},
+		{ID: uuid.New()},
+	}
+	var dummyApplication = &application{
+		name:    "some name",
+		history: newRoundHistory(10),
+	}
+
+	// stub
+	for _, record := range dummyRecords {
+		dummyApplication.history.add(record)
+	}
+
+	// SUT + act
+	var result = dummyApplication.History()
+
+	// assert
+	assert.Equal(t, dummyRecords, result)
+}
+
+func TestApplication_RoundCapStats_NotCapped(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:      "some name",
+		maxRounds: -rand.IntN(10),
+		rounds:    map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+
+	// SUT + act
+	var result = dummyApplication.RoundCapStats()
+
+	// assert
+	assert.Equal(t, RoundCapStats{Running: 1}, result)
+}
+
+func TestApplication_BreakerState(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:         "some name",
+		breakerState: BreakerStateHalfOpen,
+	}
+
+	// SUT + act
+	var result = dummyApplication.BreakerState()
+
+	// assert
+	assert.Equal(t, BreakerStateHalfOpen, result)
+}
+
+func TestApplication_RoundCapStats_Capped(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:      "some name",
+		maxRounds: 1,
+		rounds:    map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+		overflowed: []*round{
+			{id: uuid.New()},
+			{id: uuid.New()},
+		},
+		capStats: RoundCapStats{
+			Hits:    5,
+			Skipped: 2,
+			Queued:  3,
+		},
+	}
+
+	// SUT + act
+	var result = dummyApplication.RoundCapStats()
+
+	// assert
+	assert.Equal(t, RoundCapStats{Limit: 1, Running: 1, Waiting: 2, Hits: 5, Skipped: 2, Queued: 3}, result)
 }
 
 func TestApplication_Stop_NotStarted(t *testing.T) {
@@ -182,18 +379,115 @@ func TestApplication_Stop_NotStarted(t *testing.T) {
 
 func TestApplication_Stop_HasStarted(t *testing.T) {
 	// arrange
-	var dummyShutdown = make(chan bool)
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
 	var dummyApplication = &application{
-		name:     "some name",
-		shutdown: dummyShutdown,
-		started:  true,
+		name:    "some name",
+		ctx:     dummyContext,
+		cancel:  dummyCancel,
+		started: true,
 	}
 
 	// SUT + act
-	go dummyApplication.Stop()
+	dummyApplication.Stop()
 
 	// assert
-	assert.True(t, <-dummyShutdown)
+	assert.Error(t, dummyContext.Err())
+}
+
+func TestApplication_StopGracefully_NotStarted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		started: false,
+	}
+
+	// SUT + act
+	dummyApplication.StopGracefully(time.Second)
+}
+
+func TestApplication_StopGracefully_HasStarted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		started: true,
+	}
+	var dummyTimeout = time.Duration(rand.IntN(1000))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(stopGracefully).Expects(dummyApplication, dummyTimeout).Returns().Once()
+
+	// SUT + act
+	dummyApplication.StopGracefully(dummyTimeout)
+}
+
+func TestApplication_Pause(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(pauseApplication).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	dummyApplication.Pause()
+}
+
+func TestApplication_Resume(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resumeApplication).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	dummyApplication.Resume()
+}
+
+func TestApplication_IsPaused(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		paused: rand.IntN(100) > 50,
+	}
+
+	// SUT + act
+	var result = dummyApplication.IsPaused()
+
+	// assert
+	assert.Equal(t, dummyApplication.paused, result)
+}
+
+func TestApplication_TriggerNow(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyReason = "some reason"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(triggerRound).Expects(dummyApplication, dummyReason).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = dummyApplication.TriggerNow(dummyReason)
+
+	// assert
+	assert.Equal(t, dummyError, err)
 }
 
 func TestStartApplication_AlreadyStarted(t *testing.T) {
@@ -204,7 +498,18 @@ func TestStartApplication_AlreadyStarted(t *testing.T) {
 	}
 
 	// SUT + act
-	startApplication(dummyApplication)
+	startApplication(dummyApplication, context.Background())
+}
+
+func TestStartApplication_Hosted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		host: &application{},
+	}
+
+	// SUT + act
+	startApplication(dummyApplication, context.Background())
 }
 
 func TestStartApplication_PreBootstrapingFailure(t *testing.T) {
@@ -220,7 +525,7 @@ func TestStartApplication_PreBootstrapingFailure(t *testing.T) {
 	m.Mock(preBootstraping).Expects(dummyApplication).Returns(false).Once()
 
 	// SUT + act
-	startApplication(dummyApplication)
+	startApplication(dummyApplication, context.Background())
 }
 
 func TestStartApplication_PostBootstrapingFailure(t *testing.T) {
@@ -236,9 +541,10 @@ func TestStartApplication_PostBootstrapingFailure(t *testing.T) {
 	m.Mock(preBootstraping).Expects(dummyApplication).Returns(true).Once()
 	m.Mock(bootstrap).Expects(dummyApplication).Returns().Once()
 	m.Mock(postBootstraping).Expects(dummyApplication).Returns(false).Once()
+	m.Mock(stopAdminServer).Expects(dummyApplication).Returns().Once()
 
 	// SUT + act
-	startApplication(dummyApplication)
+	startApplication(dummyApplication, context.Background())
 }
 
 func TestStartApplication_HappyPath(t *testing.T) {
@@ -246,6 +552,7 @@ func TestStartApplication_HappyPath(t *testing.T) {
 	var dummyApplication = &application{
 		name: "some name",
 	}
+	var dummyContext = context.TODO()
 
 	// mock
 	var m = gomocker.NewMocker(t)
@@ -254,11 +561,12 @@ func TestStartApplication_HappyPath(t *testing.T) {
 	m.Mock(preBootstraping).Expects(dummyApplication).Returns(true).Once()
 	m.Mock(bootstrap).Expects(dummyApplication).Returns().Once()
 	m.Mock(postBootstraping).Expects(dummyApplication).Returns(true).Once()
-	m.Mock(beginApplication).Expects(dummyApplication).Returns().Once()
+	m.Mock(beginApplication).Expects(dummyApplication, dummyContext).Returns().Once()
 	m.Mock(endApplication).Expects(dummyApplication).Returns().Once()
+	m.Mock(stopAdminServer).Expects(dummyApplication).Returns().Once()
 
 	// SUT + act
-	startApplication(dummyApplication)
+	startApplication(dummyApplication, dummyContext)
 }
 
 func TestPreBootstraping_Error(t *testing.T) {
@@ -271,6 +579,7 @@ func TestPreBootstraping_Error(t *testing.T) {
 	}
 	var dummyCustomization = &customization{}
 	var dummyApplication = &application{
+		errors:        newErrorRing(10),
 		session:       dummySession,
 		customization: dummyCustomization,
 	}
@@ -292,8 +601,11 @@ func TestPreBootstraping_Error(t *testing.T) {
 
 	// assert
 	assert.False(t, result)
-	assert.Len(t, dummyApplication.lastErrors, 1)
-	assert.Equal(t, dummyError, dummyApplication.lastErrors[0])
+	var runErrors = dummyApplication.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, -1, runErrors[0].Index)
+	assert.Equal(t, RunPhasePreBootstrap, runErrors[0].Phase)
+	assert.Equal(t, dummyError, runErrors[0].Err)
 }
 
 func TestPreBootstraping_Success(t *testing.T) {
@@ -306,6 +618,7 @@ func TestPreBootstraping_Success(t *testing.T) {
 	}
 	var dummyCustomization = &customization{}
 	var dummyApplication = &application{
+		errors:        newErrorRing(10),
 		session:       dummySession,
 		customization: dummyCustomization,
 	}
@@ -326,7 +639,7 @@ func TestPreBootstraping_Success(t *testing.T) {
 
 	// assert
 	assert.True(t, result)
-	assert.Empty(t, dummyApplication.lastErrors)
+	assert.Empty(t, dummyApplication.RunErrors())
 }
 
 func TestBootstrap_HappyPath(t *testing.T) {
@@ -345,6 +658,7 @@ func TestBootstrap_HappyPath(t *testing.T) {
 	var dummyWebcallTimeout = time.Duration(rand.IntN(100))
 	var dummySkipCertVerification = rand.IntN(100) > 50
 	var dummyClientCertificate = &tls.Certificate{Certificate: [][]byte{{0}}}
+	var dummyClients = &httpClients{}
 	var dummyMessageFormat = "Application bootstrapped successfully"
 
 	// mock
@@ -352,16 +666,78 @@ func TestBootstrap_HappyPath(t *testing.T) {
 
 	// expect
 	m.Mock(initializeHTTPClients).Expects(dummyWebcallTimeout, dummySkipCertVerification,
-		dummyClientCertificate, gomocker.Anything()).Returns().Once()
+		dummyClientCertificate, gomocker.Anything()).Returns(dummyClients).Once()
 	m.Mock((*customization).DefaultTimeout).Expects(dummyCustomization).Returns(dummyWebcallTimeout).Once()
 	m.Mock((*customization).SkipServerCertVerification).Expects(dummyCustomization).Returns(dummySkipCertVerification).Once()
 	m.Mock((*customization).ClientCert).Expects(dummyCustomization).Returns(dummyClientCertificate).Once()
+	m.Mock(configureApplication).Expects(dummyApplication).Returns().Once()
+	m.Mock(startAdminServer).Expects(dummyApplication).Returns().Once()
 	m.Mock(logAppRoot).Expects(dummySession, "application", "bootstrap", dummyMessageFormat).Returns().Once()
 
 	// SUT + act
 	bootstrap(
 		dummyApplication,
 	)
+
+	// assert
+	assert.Equal(t, dummyClients, dummyApplication.clients)
+	assert.Equal(t, dummyClients, dummySession.clients)
+}
+
+func TestConfigureApplication(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		customization: dummyCustomization,
+	}
+	var dummyStore = NewFileHistoryStore("some path", 0, 0)
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyMaxRounds = rand.IntN(10)
+	var dummyOverflow = OverflowPolicy(rand.IntN(2))
+	var dummyWorkQueue = rand.IntN(100) > 50
+	var dummyRetry = RetryPolicy{MaxAttempts: rand.IntN(10)}
+	var dummyActionTimeout = time.Duration(rand.IntN(100))
+	var dummyBreaker = CircuitBreaker{FailureThreshold: rand.IntN(10)}
+	var dummyStages = []Stage{{Name: "some stage"}}
+	var dummyLocking = LockPolicy{TTL: time.Duration(rand.IntN(100)), PerInstance: true}
+	var dummyCatchUp = CatchUpPolicy{Mode: CatchUpModeAll, MaxRuns: rand.IntN(10)}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).HistoryStore).Expects(dummyCustomization).Returns(dummyStore).Once()
+	m.Mock((*customization).SpanExporter).Expects(dummyCustomization).Returns(dummyExporter).Once()
+	m.Mock((*customization).MaxConcurrentRounds).Expects(dummyCustomization).Returns(dummyMaxRounds).Once()
+	m.Mock((*customization).OverflowPolicy).Expects(dummyCustomization).Returns(dummyOverflow).Once()
+	m.Mock((*customization).WorkQueue).Expects(dummyCustomization).Returns(dummyWorkQueue).Once()
+	m.Mock((*customization).RetryPolicy).Expects(dummyCustomization).Returns(dummyRetry).Once()
+	m.Mock((*customization).ActionTimeout).Expects(dummyCustomization).Returns(dummyActionTimeout).Once()
+	m.Mock((*customization).Stages).Expects(dummyCustomization).Returns(dummyStages).Once()
+	m.Mock((*customization).CircuitBreaker).Expects(dummyCustomization).Returns(dummyBreaker).Once()
+	m.Mock((*customization).LockPolicy).Expects(dummyCustomization).Returns(dummyLocking).Once()
+	m.Mock((*customization).CatchUpPolicy).Expects(dummyCustomization).Returns(dummyCatchUp).Once()
+
+	// SUT + act
+	configureApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Equal(t, dummyStore, dummyApplication.store)
+	assert.Equal(t, dummyExporter, dummyApplication.exporter)
+	assert.Equal(t, dummyMaxRounds, dummyApplication.maxRounds)
+	assert.Equal(t, dummyOverflow, dummyApplication.overflow)
+	assert.Equal(t, dummyWorkQueue, dummyApplication.workQueue)
+	assert.Equal(t, dummyRetry, dummyApplication.retry)
+	assert.Equal(t, dummyActionTimeout, dummyApplication.actionTimeout)
+	assert.Equal(t, dummyStages, dummyApplication.stages)
+	assert.Equal(t, dummyBreaker, dummyApplication.breaker)
+	assert.Equal(t, dummyLocking, dummyApplication.locking)
+	assert.Equal(t, dummyCatchUp, dummyApplication.catchUp)
 }
 
 func TestPostBootstraping_Error(t *testing.T) {
@@ -374,6 +750,7 @@ func TestPostBootstraping_Error(t *testing.T) {
 	}
 	var dummyCustomization = &customization{}
 	var dummyApplication = &application{
+		errors:        newErrorRing(10),
 		session:       dummySession,
 		customization: dummyCustomization,
 	}
@@ -395,8 +772,11 @@ func TestPostBootstraping_Error(t *testing.T) {
 
 	// assert
 	assert.False(t, result)
-	assert.Len(t, dummyApplication.lastErrors, 1)
-	assert.Equal(t, dummyError, dummyApplication.lastErrors[0])
+	var runErrors = dummyApplication.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, -1, runErrors[0].Index)
+	assert.Equal(t, RunPhasePostBootstrap, runErrors[0].Phase)
+	assert.Equal(t, dummyError, runErrors[0].Err)
 }
 
 func TestPostBootstraping_Success(t *testing.T) {
@@ -409,6 +789,7 @@ func TestPostBootstraping_Success(t *testing.T) {
 	}
 	var dummyCustomization = &customization{}
 	var dummyApplication = &application{
+		errors:        newErrorRing(10),
 		session:       dummySession,
 		customization: dummyCustomization,
 	}
@@ -429,7 +810,7 @@ func TestPostBootstraping_Success(t *testing.T) {
 
 	// assert
 	assert.True(t, result)
-	assert.Empty(t, dummyApplication.lastErrors)
+	assert.Empty(t, dummyApplication.RunErrors())
 }
 
 func TestWaitForNextRun_NilNextSchedule(t *testing.T) {
@@ -444,6 +825,7 @@ func TestWaitForNextRun_NilNextSchedule(t *testing.T) {
 		schedule: dummySchedule,
 		session:  dummySession,
 		started:  true,
+		nextRun:  time.Now(),
 	}
 	var dummyTimeNext *time.Time
 	var dummyMessageFormat = "No next schedule available, terminating execution"
@@ -457,12 +839,14 @@ func TestWaitForNextRun_NilNextSchedule(t *testing.T) {
 		"waitForNextRun", dummyMessageFormat).Returns().Once()
 
 	// SUT + act
-	waitForNextRun(
+	var timeNext, result = waitForNextRun(
 		dummyApplication,
 	)
 
 	// assert
-	assert.False(t, dummyApplication.started)
+	assert.Zero(t, timeNext)
+	assert.False(t, result)
+	assert.Zero(t, dummyApplication.nextRun)
 }
 
 func TestWaitForNextRun_ValidNextSchedule(t *testing.T) {
@@ -473,9 +857,10 @@ func TestWaitForNextRun_ValidNextSchedule(t *testing.T) {
 	var dummySchedule = &schedule{}
 	var dummySession = &session{id: uuid.New()}
 	var dummyApplication = &application{
-		name:     "some name",
-		schedule: dummySchedule,
-		session:  dummySession,
+		name:       "some name",
+		schedule:   dummySchedule,
+		session:    dummySession,
+		scheduling: context.Background(),
 	}
 	var dummyTimeNow = time.Now()
 	var dummyDuration = time.Duration(rand.IntN(1000)) + 10*time.Second
@@ -493,140 +878,1870 @@ func TestWaitForNextRun_ValidNextSchedule(t *testing.T) {
 		dummyMessageFormat, dummyTimeNext, dummyDuration).Returns().Once()
 	m.Mock(time.After).Expects(dummyDuration).Returns(dummyControlChannel).Once()
 
-	// SUT + act
-	go waitForNextRun(
-		dummyApplication,
-	)
+	// SUT
+	var timeNext time.Time
+	var result = make(chan bool)
+	go func() {
+		var proceed bool
+		timeNext, proceed = waitForNextRun(
+			dummyApplication,
+		)
+		result <- proceed
+	}()
 
-	// push
+	// act
 	dummyControlChannel <- dummyTimeNext
-}
-
-func TestRunInstances_ZeroInstance(t *testing.T) {
-	// arrange
-	var dummyApplication = &application{}
 
-	// SUT + act
-	runInstances(
-		dummyApplication,
-	)
+	// assert
+	assert.True(t, <-result)
+	assert.Equal(t, dummyTimeNext, timeNext)
+	assert.Equal(t, dummyTimeNext, dummyApplication.nextRun)
 }
 
-func TestRunInstances_SingleInstance(t *testing.T) {
+func TestWaitForNextRun_ContextCancelled(t *testing.T) {
 	// arrange
-	var dummyReruns = rand.Int32N(65535)
+	type schedule struct {
+		Schedule
+	}
+	var dummySchedule = &schedule{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
 	var dummyApplication = &application{
-		instances: 1,
-		reruns:    []int32{dummyReruns},
+		name:       "some name",
+		schedule:   dummySchedule,
+		session:    dummySession,
+		scheduling: dummyContext,
 	}
-	var dummyError = errors.New("some error")
+	var dummyTimeNow = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000)) + 10*time.Second
+	var dummyTimeNext = dummyTimeNow.Add(dummyDuration)
+	var dummyMessageFormat = "Next run at [%v]: waiting for [%v]"
+	var dummyControlChannel = make(chan time.Time)
+
+	// stub
+	dummyCancel()
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(handleSession).Expects(dummyApplication, 0, int(dummyReruns)+1).Returns(dummyError).Once()
+	m.Mock((*schedule).NextSchedule).Expects(dummySchedule).Returns(&dummyTimeNext).Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "waitForNextRun",
+		dummyMessageFormat, dummyTimeNext, dummyDuration).Returns().Once()
+	m.Mock(time.After).Expects(dummyDuration).Returns(dummyControlChannel).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "waitForNextRun",
+		"Scheduling halted, terminating execution").Returns().Once()
 
 	// SUT + act
-	runInstances(
+	var timeNext, result = waitForNextRun(
 		dummyApplication,
 	)
 
 	// assert
-	assert.Len(t, dummyApplication.lastErrors, 1)
-	assert.Equal(t, dummyError, dummyApplication.lastErrors[0])
+	assert.Zero(t, timeNext)
+	assert.False(t, result)
 }
 
-func TestRunInstances_MultipleInstances(t *testing.T) {
+func TestResolveInstanceCount_NotCustomized(t *testing.T) {
 	// arrange
-	var dummyErrors = []error{
-		errors.New("some error 1"),
-		errors.New("some error 2"),
-		errors.New("some error 3"),
-	}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		instances:     rand.IntN(100),
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRoundInfo = RoundInfo{ID: dummyRound.id}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRoundInfo).Expects(dummyRound).Returns(dummyRoundInfo).Once()
+	m.Mock((*customization).InstanceCount).Expects(dummyCustomization, dummyRoundInfo).Returns(-rand.IntN(10)).Once()
+
+	// SUT + act
+	var result = resolveInstanceCount(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummyApplication.instances, result)
+}
+
+func TestResolveInstanceCount_Customized(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		instances:     rand.IntN(100),
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRoundInfo = RoundInfo{ID: dummyRound.id}
+	var dummyCount = 1 + rand.IntN(100)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRoundInfo).Expects(dummyRound).Returns(dummyRoundInfo).Once()
+	m.Mock((*customization).InstanceCount).Expects(dummyCustomization, dummyRoundInfo).Returns(dummyCount).Once()
+
+	// SUT + act
+	var result = resolveInstanceCount(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummyCount, result)
+}
+
+func TestNextReruns_Existing(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		reruns: []int{3, 5},
+	}
+
+	// SUT + act
+	var result = nextReruns(
+		dummyApplication,
+		1,
+	)
+
+	// assert
+	assert.Equal(t, 6, result)
+	assert.Equal(t, []int{3, 6}, dummyApplication.reruns)
+}
+
+func TestNextReruns_Grow(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		reruns: []int{3},
+	}
+
+	// SUT + act
+	var result = nextReruns(
+		dummyApplication,
+		2,
+	)
+
+	// assert
+	assert.Equal(t, 1, result)
+	assert.Equal(t, []int{3, 0, 1}, dummyApplication.reruns)
+}
+
+func TestRunInstances_ZeroInstance(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		history: newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(0).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, dummyRound.id, history[0].ID)
+	assert.Empty(t, history[0].Instances)
+}
+
+func TestRunInstances_Traced(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyApplication = &application{
+		name:     "some name",
+		history:  newRoundHistory(10),
+		exporter: dummyExporter,
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(0).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, dummyRound.id, history[0].ID)
+	assert.Empty(t, history[0].Instances)
+	var spans = dummyExporter.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, dummyRound.span, spans[0])
+	assert.Equal(t, "some name", spans[0].Attributes["app.name"])
+	assert.Equal(t, dummyRound.id.String(), spans[0].Attributes["round.id"])
+	assert.Equal(t, "Completed", spans[0].Attributes["round.status"])
+	assert.Equal(t, 0, spans[0].Attributes["round.instances"])
+}
+
+func TestRunInstances_Locked(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyApplication = &application{
+		history:  newRoundHistory(10),
+		exporter: dummyExporter,
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(lockRound).Expects(dummyApplication, dummyRound).Returns(nil, false).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, dummyRound.id, history[0].ID)
+	assert.Equal(t, RoundStatusLocked, history[0].Status)
+	assert.Empty(t, history[0].Instances)
+	assert.True(t, dummyRound.lockedOut.Load())
+	var spans = dummyExporter.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "round", spans[0].Name)
+	assert.Equal(t, dummyRound.span, spans[0])
+	assert.Equal(t, "Locked", spans[0].Attributes["round.status"])
+}
+
+func TestRunInstances_LockReleased(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		history: newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+	var releaseCalled = 0
+	var dummyRelease = func() {
+		releaseCalled++
+		assert.Len(t, dummyApplication.History(), 1)
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(lockRound).Expects(dummyApplication, dummyRound).Returns(dummyRelease, true).Once()
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(0).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 1, releaseCalled)
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, RoundStatusCompleted, history[0].Status)
+}
+
+func TestPartitionRound_Panic(t *testing.T) {
+	// arrange
+	var dummyAppSession = &session{id: uuid.New()}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          "some name",
+		session:       dummyAppSession,
+		customization: dummyCustomization,
+		errors:        newErrorRing(10),
+	}
+	var dummyRound = &round{id: uuid.New(), instances: rand.IntN(100)}
+	var dummySession = &session{id: uuid.New()}
+	var dummyPanic = "some panic"
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{Err: dummyError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, -1, 0).Returns(dummySession).Once()
+	m.Mock((*customization).Partition).Expects(dummyCustomization, dummySession, dummyRound.instances).Returns(nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { panic(dummyPanic) })).Once()
+	m.Mock(finalizeSession).Expects(dummySession, nil, dummyPanic).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummyAppSession, "application", "partitionRound",
+		"Failed to partition round [%v] into [%v] shard(s). Error: %+v",
+		dummyRound.id, dummyRound.instances, dummyError).Returns().Once()
+	m.Mock(newRunError).Expects(dummyRound, -1, 0, RunPhasePanic, dummyError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var result = partitionRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Nil(t, dummyRound.payloads)
+	assert.True(t, dummyRound.failed.Load())
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}
+
+func TestPartitionRound_HappyPath(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          "some name",
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{id: uuid.New(), instances: 2}
+	var dummySession = &session{id: uuid.New()}
+	var dummyPayloads = []any{"some payload 1", "some payload 2"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, -1, 0).Returns(dummySession).Once()
+	m.Mock((*customization).Partition).Expects(dummyCustomization, dummySession, 2).Returns(dummyPayloads).Once()
+
+	// SUT + act
+	var result = partitionRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, dummyPayloads, dummyRound.payloads)
+}
+
+func TestRunInstances_PartitionFailed(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		history: newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(3).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(false).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+	assert.Zero(t, dummyRound.instances)
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Empty(t, history[0].Instances)
+}
+
+func TestRunInstances_WorkQueue_ProduceFailed(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		workQueue: true,
+		history:   newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(3).Once()
+	m.Mock(produceWork).Expects(dummyApplication, dummyRound).Returns(nil).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+	assert.Zero(t, dummyRound.instances)
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Empty(t, history[0].Instances)
+}
+
+func TestRunInstances_WorkQueue_Produced(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		instances: 1,
+		reruns:    []int{0},
+		workQueue: true,
+		errors:    newErrorRing(10),
+		history:   newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+	var dummyWork = make(chan *workItem)
+	var dummyRecord = &InstanceRecord{Items: []*WorkItemRecord{{Sequence: 0}}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(1).Once()
+	m.Mock(produceWork).Expects(dummyApplication, dummyRound).Returns((<-chan *workItem)(dummyWork)).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, 0, 1).Returns(dummyRecord).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 1, dummyRound.instances)
+	assert.Equal(t, (<-chan *workItem)(dummyWork), dummyRound.work)
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, []*InstanceRecord{dummyRecord}, history[0].Instances)
+}
+
+func TestRunInstances_SingleInstance(t *testing.T) {
+	// arrange
+	var dummyReruns = rand.IntN(65535)
+	var dummyApplication = &application{
+		instances: 1,
+		reruns:    []int{dummyReruns},
+		errors:    newErrorRing(10),
+		history:   newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+	var dummyRunError = &RunError{Err: errors.New("some error")}
+	var dummyRecord = &InstanceRecord{Err: dummyRunError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(1).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, 0, dummyReruns+1).Returns(dummyRecord).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { assert.NoError(t, dummyContext.Err()) })).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+	assert.Equal(t, 1, dummyRound.instances)
+	assert.Equal(t, []int{dummyReruns + 1}, dummyApplication.reruns)
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, dummyRound.id, history[0].ID)
+	assert.Equal(t, []*InstanceRecord{dummyRecord}, history[0].Instances)
+}
+
+func TestRunInstances_Stopped(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		instances: 1,
+		reruns:    make([]int, 1),
+		errors:    newErrorRing(10),
+		history:   newRoundHistory(10),
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+	var dummyRecord = &InstanceRecord{Outcome: InstanceOutcomeSuccess}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(1).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, 0, 1).Returns(dummyRecord).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { dummyCancel() })).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, dummyRound.cancelled.Load())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, RoundStatusCancelled, history[0].Status)
+}
+
+func TestRunInstances_MultipleInstances(t *testing.T) {
+	// arrange
+	var dummyRunErrors = []*RunError{
+		{Err: errors.New("some error 1")},
+		{Err: errors.New("some error 2")},
+	}
+	var dummyRecords = []*InstanceRecord{
+		{Err: dummyRunErrors[0]},
+		{Err: dummyRunErrors[1]},
+		{Outcome: InstanceOutcomeSuccess},
+	}
+	var dummyApplication = &application{
+		instances: 1,
+		reruns:    make([]int, 1),
+		errors:    newErrorRing(10),
+		history:   newRoundHistory(10),
+	}
+	var dummyRound = &round{ctx: context.Background(), cancel: func() {}}
+	var calls = map[int]bool{}
+	var lock = sync.RWMutex{}
+
+	// stub
+	var callChecker = func(value any) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		var called, found = calls[value.(int)]
+		if found || called {
+			return false
+		}
+		calls[value.(int)] = true
+		return true
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(3).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, gomocker.Matches(callChecker), 1).Returns(dummyRecords[0]).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, gomocker.Matches(callChecker), 1).Returns(dummyRecords[1]).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, gomocker.Matches(callChecker), 1).Returns(dummyRecords[2]).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 3, dummyRound.instances)
+	assert.Equal(t, []int{1, 1, 1}, dummyApplication.reruns)
+	assert.True(t, dummyRound.failed.Load())
+	assert.False(t, dummyRound.lockedOut.Load())
+	assert.ElementsMatch(t, dummyRunErrors, dummyApplication.RunErrors())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.ElementsMatch(t, dummyRecords, history[0].Instances)
+}
+
+func TestRunInstances_AllInstancesLocked(t *testing.T) {
+	// arrange
+	var dummyRecords = []*InstanceRecord{
+		{Index: 0, Outcome: InstanceOutcomeLocked},
+		{Index: 1, Outcome: InstanceOutcomeLocked},
+	}
+	var dummyApplication = &application{
+		instances: 1,
+		reruns:    make([]int, 1),
+		errors:    newErrorRing(10),
+		history:   newRoundHistory(10),
+	}
+	var dummyRound = &round{ctx: context.Background(), cancel: func() {}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(resolveInstanceCount).Expects(dummyApplication, dummyRound).Returns(2).Once()
+	m.Mock(partitionRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+	m.Mock(handleLockedSession).Expects(dummyApplication, dummyRound, 0, 1).Returns(dummyRecords[0]).Once()
+	m.Mock(handleLockedSession).Expects(dummyApplication, dummyRound, 1, 1).Returns(dummyRecords[1]).Once()
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, dummyRound.lockedOut.Load())
+	assert.False(t, dummyRound.failed.Load())
+	assert.Empty(t, dummyApplication.RunErrors())
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.ElementsMatch(t, dummyRecords, history[0].Instances)
+}
+
+func TestAdmitRound_NoneRunning(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		overlap: OverlapPolicy(rand.IntN(4)),
+		ctx:     context.Background(),
+		rounds:  map[uuid.UUID]*round{},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, admitted)
+	assert.Empty(t, previous)
+	assert.NoError(t, dummyRound.ctx.Err())
+	assert.NotNil(t, dummyRound.cancel)
+	assert.Equal(t, map[uuid.UUID]*round{dummyRound.id: dummyRound}, dummyApplication.rounds)
+}
+
+func TestAdmitRound_Skip(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		overlap: OverlapPolicySkip,
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRecord = &RoundRecord{ID: dummyRound.id}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "admitRound",
+		"Round [%v] skipped by overlap policy [%v] with [%v] round(s) still running",
+		dummyRound.id, OverlapPolicySkip, 1).Returns().Once()
+	m.Mock(newDroppedRoundRecord).Expects(dummyRound, RoundStatusSkipped).Returns(dummyRecord).Once()
+	m.Mock(recordRound).Expects(dummyApplication, dummyRecord).Returns().Once()
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, admitted)
+	assert.Empty(t, previous)
+	assert.Len(t, dummyApplication.rounds, 1)
+}
+
+func TestAdmitRound_QueueOne_Queued(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		overlap: OverlapPolicyQueueOne,
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "admitRound",
+		"Round [%v] queued by overlap policy [%v] with [%v] round(s) still running",
+		dummyRound.id, OverlapPolicyQueueOne, 1).Returns().Once()
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, admitted)
+	assert.Empty(t, previous)
+	assert.Equal(t, dummyRound, dummyApplication.pending)
+	assert.Len(t, dummyApplication.rounds, 1)
+}
+
+func TestAdmitRound_QueueOne_Coalesced(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyPending = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		overlap: OverlapPolicyQueueOne,
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+		pending: dummyPending,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRecord = &RoundRecord{ID: dummyRound.id}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "admitRound",
+		"Round [%v] coalesced into queued round [%v] by overlap policy [%v]",
+		dummyRound.id, dummyPending.id, OverlapPolicyQueueOne).Returns().Once()
+	m.Mock(newDroppedRoundRecord).Expects(dummyRound, RoundStatusCoalesced).Returns(dummyRecord).Once()
+	m.Mock(recordRound).Expects(dummyApplication, dummyRecord).Returns().Once()
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, admitted)
+	assert.Empty(t, previous)
+	assert.Equal(t, dummyPending, dummyApplication.pending)
+	assert.Len(t, dummyApplication.rounds, 1)
+}
+
+func TestAdmitRound_Allow(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		overlap: OverlapPolicyAllow,
+		ctx:     context.Background(),
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, admitted)
+	assert.Empty(t, previous)
+	assert.NotNil(t, dummyRound.ctx)
+	assert.Len(t, dummyApplication.rounds, 2)
+}
+
+func TestAdmitRound_CancelPrevious(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		overlap: OverlapPolicyCancelPrevious,
+		ctx:     context.Background(),
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, admitted)
+	assert.Equal(t, []*round{dummyRunning}, previous)
+	assert.NotNil(t, dummyRound.ctx)
+	assert.Len(t, dummyApplication.rounds, 2)
+}
+
+func TestAdmitRound_Overflow_Skip(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:      "some name",
+		session:   dummySession,
+		overlap:   OverlapPolicyAllow,
+		maxRounds: 1,
+		overflow:  OverflowPolicySkip,
+		rounds:    map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRecord = &RoundRecord{ID: dummyRound.id}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "admitRound",
+		"Round [%v] skipped by overflow policy [%v] with [%v] round(s) running at the cap",
+		dummyRound.id, OverflowPolicySkip, 1).Returns().Once()
+	m.Mock(newDroppedRoundRecord).Expects(dummyRound, RoundStatusSkipped).Returns(dummyRecord).Once()
+	m.Mock(recordRound).Expects(dummyApplication, dummyRecord).Returns().Once()
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, admitted)
+	assert.Empty(t, previous)
+	assert.Len(t, dummyApplication.rounds, 1)
+	assert.Empty(t, dummyApplication.overflowed)
+	assert.Equal(t, RoundCapStats{Hits: 1, Skipped: 1}, dummyApplication.capStats)
+}
+
+func TestAdmitRound_Overflow_Queue(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyWaiting = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		overlap:    OverlapPolicyAllow,
+		maxRounds:  1,
+		overflow:   OverflowPolicyQueue,
+		rounds:     map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+		overflowed: []*round{dummyWaiting},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "admitRound",
+		"Round [%v] queued by overflow policy [%v] with [%v] round(s) running at the cap and [%v] round(s) waiting",
+		dummyRound.id, OverflowPolicyQueue, 1, 2).Returns().Once()
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, admitted)
+	assert.Empty(t, previous)
+	assert.Len(t, dummyApplication.rounds, 1)
+	assert.Equal(t, []*round{dummyWaiting, dummyRound}, dummyApplication.overflowed)
+	assert.Equal(t, RoundCapStats{Hits: 1, Queued: 1}, dummyApplication.capStats)
+}
+
+func TestAdmitRound_UnderCap(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:      "some name",
+		overlap:   OverlapPolicyAllow,
+		maxRounds: 2,
+		ctx:       context.Background(),
+		rounds:    map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, admitted)
+	assert.Empty(t, previous)
+	assert.Len(t, dummyApplication.rounds, 2)
+	assert.Zero(t, dummyApplication.capStats)
+}
+
+func TestAdmitRound_CancelPrevious_AtCap(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:      "some name",
+		overlap:   OverlapPolicyCancelPrevious,
+		maxRounds: 1,
+		overflow:  OverflowPolicySkip,
+		ctx:       context.Background(),
+		rounds:    map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var admitted, previous = admitRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, admitted)
+	assert.Equal(t, []*round{dummyRunning}, previous)
+	assert.Len(t, dummyApplication.rounds, 2)
+	assert.Zero(t, dummyApplication.capStats)
+}
+
+func TestRegisterRound_NoCancel(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		overlap: OverlapPolicyAllow,
+		ctx:     context.Background(),
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var result = registerRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Empty(t, result)
+	assert.NoError(t, dummyRound.ctx.Err())
+	assert.NotNil(t, dummyRound.cancel)
+	assert.Equal(t, dummyRound, dummyApplication.rounds[dummyRound.id])
+}
+
+func TestRegisterRound_CancelPrevious(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		overlap: OverlapPolicyCancelPrevious,
+		ctx:     context.Background(),
+		rounds:  map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var result = registerRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, []*round{dummyRunning}, result)
+	assert.NoError(t, dummyRound.ctx.Err())
+	assert.Len(t, dummyApplication.rounds, 2)
+}
+
+func TestReleaseRound_SchedulingHalted(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	var dummyApplication = &application{
+		name:       "some name",
+		rounds:     map[uuid.UUID]*round{dummyRound.id: dummyRound},
+		pending:    &round{id: uuid.New()},
+		overflowed: []*round{{id: uuid.New()}},
+		scheduling: dummyScheduling,
+	}
+
+	// stub
+	dummyHalt()
+
+	// SUT + act
+	var next, previous = releaseRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Nil(t, next)
+	assert.Nil(t, previous)
+	assert.Empty(t, dummyApplication.rounds)
+	assert.Nil(t, dummyApplication.pending)
+	assert.Empty(t, dummyApplication.overflowed)
+}
+
+func TestReleaseRound_Overflowed(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyWaiting1 = &round{id: uuid.New()}
+	var dummyWaiting2 = &round{id: uuid.New()}
+	var dummyPrevious = []*round{dummyRunning}
+	var dummyApplication = &application{
+		name: "some name",
+		rounds: map[uuid.UUID]*round{
+			dummyRound.id:   dummyRound,
+			dummyRunning.id: dummyRunning,
+		},
+		overflowed: []*round{dummyWaiting1, dummyWaiting2},
+		scheduling: context.Background(),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(registerRound).Expects(dummyApplication, dummyWaiting1).Returns(dummyPrevious).Once()
+
+	// SUT + act
+	var next, previous = releaseRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummyWaiting1, next)
+	assert.Equal(t, dummyPrevious, previous)
+	assert.Equal(t, map[uuid.UUID]*round{dummyRunning.id: dummyRunning}, dummyApplication.rounds)
+	assert.Equal(t, []*round{dummyWaiting2}, dummyApplication.overflowed)
+}
+
+func TestReleaseRound_OthersRunning(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyPending = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name: "some name",
+		rounds: map[uuid.UUID]*round{
+			dummyRound.id:   dummyRound,
+			dummyRunning.id: dummyRunning,
+		},
+		pending:    dummyPending,
+		scheduling: context.Background(),
+	}
+
+	// SUT + act
+	var next, previous = releaseRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Nil(t, next)
+	assert.Nil(t, previous)
+	assert.Equal(t, map[uuid.UUID]*round{dummyRunning.id: dummyRunning}, dummyApplication.rounds)
+	assert.Equal(t, dummyPending, dummyApplication.pending)
+}
+
+func TestReleaseRound_NoPending(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:       "some name",
+		rounds:     map[uuid.UUID]*round{dummyRound.id: dummyRound},
+		scheduling: context.Background(),
+	}
+
+	// SUT + act
+	var next, previous = releaseRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Nil(t, next)
+	assert.Nil(t, previous)
+	assert.Empty(t, dummyApplication.rounds)
+}
+
+func TestReleaseRound_Pending(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyPending = &round{id: uuid.New()}
+	var dummyPrevious = []*round{}
+	var dummyApplication = &application{
+		name:       "some name",
+		rounds:     map[uuid.UUID]*round{dummyRound.id: dummyRound},
+		pending:    dummyPending,
+		scheduling: context.Background(),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(registerRound).Expects(dummyApplication, dummyPending).Returns(dummyPrevious).Once()
+
+	// SUT + act
+	var next, previous = releaseRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummyPending, next)
+	assert.Equal(t, dummyPrevious, previous)
+	assert.Empty(t, dummyApplication.rounds)
+	assert.Nil(t, dummyApplication.pending)
+}
+
+func TestCancelRound(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		overlap: OverlapPolicyCancelPrevious,
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRound = &round{id: uuid.New(), ctx: dummyContext, cancel: dummyCancel}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "cancelRound",
+		"Round [%v] cancelled by overlap policy [%v] in favor of a new round",
+		dummyRound.id, OverlapPolicyCancelPrevious).Returns().Once()
+
+	// SUT + act
+	cancelRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, dummyRound.cancelled.Load())
+	assert.Error(t, dummyContext.Err())
+}
+
+func TestCompleteRound_NoNext(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyRound = &round{}
+
+	// stub
+	dummyApplication.waits.Add(1)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runInstances).Expects(dummyApplication, dummyRound).Returns().Once()
+	m.Mock(recordBreakerResult).Expects(dummyApplication, dummyRound).Returns().Once()
+	m.Mock(releaseRound).Expects(dummyApplication, dummyRound).Returns(nil, nil).Once()
+
+	// SUT + act
+	completeRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	dummyApplication.waits.Wait()
+}
+
+func TestCompleteRound_WithNext(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyRound = &round{}
+	var dummyNext = &round{}
+	var dummyPrevious = []*round{{}}
+
+	// stub
+	dummyApplication.waits.Add(1)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runInstances).Expects(dummyApplication, dummyRound).Returns().Once()
+	m.Mock(recordBreakerResult).Expects(dummyApplication, dummyRound).Returns().Once()
+	m.Mock(releaseRound).Expects(dummyApplication, dummyRound).Returns(dummyNext, dummyPrevious).Once()
+	m.Mock(launchRound).Expects(dummyApplication, dummyNext, dummyPrevious).Returns().Once()
+
+	// SUT + act
+	completeRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	dummyApplication.waits.Wait()
+}
+
+func TestCompleteRound_LockedProbe(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New(), cancel: func() {}}
+	var dummyApplication = &application{
+		name:         "some name",
+		history:      newRoundHistory(10),
+		rounds:       map[uuid.UUID]*round{dummyRound.id: dummyRound},
+		breaker:      CircuitBreaker{FailureThreshold: 3, CoolDown: time.Minute},
+		breakerState: BreakerStateHalfOpen,
+		failures:     3,
+		probe:        dummyRound.id,
+		scheduling:   context.Background(),
+	}
+
+	// stub
+	dummyApplication.waits.Add(1)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(lockRound).Expects(dummyApplication, dummyRound).Returns(nil, false).Once()
+
+	// SUT + act
+	completeRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	dummyApplication.waits.Wait()
+	assert.Equal(t, BreakerStateHalfOpen, dummyApplication.breakerState)
+	assert.Equal(t, 3, dummyApplication.failures)
+	assert.False(t, isRoundAlive(dummyApplication, dummyApplication.probe))
+	var history = dummyApplication.History()
+	assert.Len(t, history, 1)
+	assert.Equal(t, RoundStatusLocked, history[0].Status)
+}
+
+func TestLaunchRound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyRound = &round{}
+	var dummyPrevious = &round{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(cancelRound).Expects(dummyApplication, dummyPrevious).Returns().Once()
+	m.Mock(completeRound).Expects(dummyApplication, dummyRound).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() { dummyApplication.waits.Done() })).Once()
+
+	// SUT + act
+	launchRound(
+		dummyApplication,
+		dummyRound,
+		[]*round{dummyPrevious},
+	)
+
+	// assert
+	dummyApplication.waits.Wait()
+}
+
+func TestDispatchRound_NotAdmitted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyRound = &round{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(admitRound).Expects(dummyApplication, dummyRound).Returns(false, nil).Once()
+
+	// SUT + act
+	dispatchRound(
+		dummyApplication,
+		dummyRound,
+	)
+}
+
+func TestDispatchRound_Admitted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyRound = &round{}
+	var dummyPrevious = []*round{{}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(admitRound).Expects(dummyApplication, dummyRound).Returns(true, dummyPrevious).Once()
+	m.Mock(launchRound).Expects(dummyApplication, dummyRound, dummyPrevious).Returns().Once()
+
+	// SUT + act
+	dispatchRound(
+		dummyApplication,
+		dummyRound,
+	)
+}
+
+func TestTriggerRound_NotStarted(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name:    dummyName,
+		started: false,
+	}
+	var dummyReason = "some reason"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(fmt.Errorf).Expects("Runner [%v] is not running, unable to trigger a round", dummyName).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = triggerRound(
+		dummyApplication,
+		dummyReason,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestTriggerRound_SchedulingHalted(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	var dummyApplication = &application{
+		name:       dummyName,
+		started:    true,
+		scheduling: dummyScheduling,
+	}
+	var dummyReason = "some reason"
+	var dummyError = errors.New("some error")
+
+	// stub
+	dummyHalt()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(fmt.Errorf).Expects("Runner [%v] is not running, unable to trigger a round", dummyName).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = triggerRound(
+		dummyApplication,
+		dummyReason,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestTriggerRound_Dropped(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummySession = &session{id: uuid.New()}
 	var dummyApplication = &application{
-		instances: 3,
-		reruns:    make([]int32, 3),
+		name:       dummyName,
+		session:    dummySession,
+		started:    true,
+		scheduling: context.Background(),
+		overlap:    OverlapPolicySkip,
+		overflow:   OverflowPolicySkip,
 	}
-	var calls = map[int]bool{}
-	var lock = sync.RWMutex{}
+	var dummyReason = "some reason"
+	var dummyRound = &round{id: uuid.New()}
+	var dummyError = errors.New("some error")
 
-	// stub
-	var callChecker = func(value any) bool {
-		lock.Lock()
-		defer lock.Unlock()
-		var called, found = calls[value.(int)]
-		if found || called {
-			return false
-		}
-		calls[value.(int)] = true
-		return true
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "triggerRound",
+		"Round triggered manually: %v", dummyReason).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceManual, dummyReason, gomocker.Anything()).Returns(dummyRound).Once()
+	m.Mock(admitRound).Expects(dummyApplication, dummyRound).Returns(false, nil).Once()
+	m.Mock(isRoundAlive).Expects(dummyApplication, dummyRound.id).Returns(false).Once()
+	m.Mock(fmt.Errorf).Expects("Runner [%v] dropped the triggered round [%v] due to overlap policy [%v] or overflow policy [%v]",
+		dummyName, dummyRound.id, OverlapPolicySkip, OverflowPolicySkip).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = triggerRound(
+		dummyApplication,
+		dummyReason,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestTriggerRound_Queued(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		started:    true,
+		scheduling: context.Background(),
+	}
+	var dummyReason = "some reason"
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "triggerRound",
+		"Round triggered manually: %v", dummyReason).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceManual, dummyReason, gomocker.Anything()).Returns(dummyRound).Once()
+	m.Mock(admitRound).Expects(dummyApplication, dummyRound).Returns(false, nil).Once()
+	m.Mock(isRoundAlive).Expects(dummyApplication, dummyRound.id).Returns(true).Once()
+
+	// SUT + act
+	var err = triggerRound(
+		dummyApplication,
+		dummyReason,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestTriggerRound_HappyPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		started:    true,
+		scheduling: context.Background(),
+	}
+	var dummyReason = "some reason"
+	var dummyRound = &round{}
+	var dummyPrevious = []*round{{id: uuid.New()}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "triggerRound",
+		"Round triggered manually: %v", dummyReason).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceManual, dummyReason, gomocker.Anything()).Returns(dummyRound).Once()
+	m.Mock(admitRound).Expects(dummyApplication, dummyRound).Returns(true, dummyPrevious).Once()
+	m.Mock(launchRound).Expects(dummyApplication, dummyRound, dummyPrevious).Returns().Once()
+
+	// SUT + act
+	var err = triggerRound(
+		dummyApplication,
+		dummyReason,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestHoldPausedRound_NotPaused(t *testing.T) {
+	// arrange
+	var dummyScheduled = time.Now()
+	var dummyApplication = &application{
+		name:   "some name",
+		paused: false,
+	}
+
+	// SUT + act
+	var result = holdPausedRound(
+		dummyApplication,
+		dummyScheduled,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestHoldPausedRound_Skip(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          "some name",
+		session:       dummySession,
+		customization: dummyCustomization,
+		paused:        true,
+		queued:        []time.Time{time.Now().Add(-time.Minute)},
+	}
+	var dummyScheduled = time.Now()
+	var dummyQueued = dummyApplication.queued
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).PausePolicy).Expects(dummyCustomization).Returns(PausePolicySkip).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "holdPausedRound",
+		"Runner paused, round held back by policy [%v] with [%v] round(s) queued",
+		PausePolicySkip, 1).Returns().Once()
+
+	// SUT + act
+	var result = holdPausedRound(
+		dummyApplication,
+		dummyScheduled,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, dummyQueued, dummyApplication.queued)
+}
+
+func TestHoldPausedRound_Queue(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          "some name",
+		session:       dummySession,
+		customization: dummyCustomization,
+		paused:        true,
+		queued:        []time.Time{time.Now().Add(-time.Minute)},
+	}
+	var dummyScheduled = time.Now()
+	var dummyQueued = []time.Time{dummyApplication.queued[0], dummyScheduled}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).PausePolicy).Expects(dummyCustomization).Returns(PausePolicyQueue).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "holdPausedRound",
+		"Runner paused, round held back by policy [%v] with [%v] round(s) queued",
+		PausePolicyQueue, 2).Returns().Once()
+
+	// SUT + act
+	var result = holdPausedRound(
+		dummyApplication,
+		dummyScheduled,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, dummyQueued, dummyApplication.queued)
+}
+
+func TestPauseApplication_AlreadyPaused(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		paused: true,
+	}
+
+	// SUT + act
+	pauseApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.True(t, dummyApplication.paused)
+}
+
+func TestPauseApplication_HappyPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		paused:  false,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "pauseApplication",
+		"Runner paused").Returns().Once()
+
+	// SUT + act
+	pauseApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.True(t, dummyApplication.paused)
+}
+
+func TestResumeApplication_NotPaused(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		paused: false,
+	}
+
+	// SUT + act
+	resumeApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.False(t, dummyApplication.paused)
+}
+
+func TestResumeApplication_NoneQueued(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		paused:     true,
+		scheduling: context.Background(),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "resumeApplication",
+		"Runner resumed with [%v] round(s) queued", 0).Returns().Once()
+
+	// SUT + act
+	resumeApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.False(t, dummyApplication.paused)
+}
+
+func TestResumeApplication_SchedulingStopped(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	dummyHalt()
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		paused:     true,
+		queued:     []time.Time{time.Now().Add(-time.Minute)},
+		scheduling: dummyScheduling,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "resumeApplication",
+		"Runner resumed with [%v] round(s) queued", 1).Returns().Once()
+
+	// SUT + act
+	resumeApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.False(t, dummyApplication.paused)
+	assert.Empty(t, dummyApplication.queued)
+}
+
+func TestResumeApplication_HaltedWhileReplaying(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	defer dummyHalt()
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		paused:     true,
+		queued:     []time.Time{time.Now().Add(-2 * time.Minute), time.Now().Add(-time.Minute)},
+		scheduling: dummyScheduling,
+	}
+	var dummyQueued = dummyApplication.queued
+	var dummyRound = &round{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "resumeApplication",
+		"Runner resumed with [%v] round(s) queued", 2).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceScheduled, "", dummyQueued[0]).Returns(dummyRound).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound).Returns(false).Once()
+	m.Mock(dispatchRound).Expects(dummyApplication, dummyRound).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() { dummyHalt() })).Once()
+
+	// SUT + act
+	resumeApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.False(t, dummyApplication.paused)
+	assert.Empty(t, dummyApplication.queued)
+}
+
+func TestResumeApplication_HappyPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		paused:     true,
+		queued:     []time.Time{time.Now().Add(-2 * time.Minute), time.Now().Add(-time.Minute)},
+		scheduling: context.Background(),
+	}
+	var dummyQueued = dummyApplication.queued
+	var dummyRound1 = &round{}
+	var dummyRound2 = &round{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "resumeApplication",
+		"Runner resumed with [%v] round(s) queued", 2).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceScheduled, "", dummyQueued[0]).Returns(dummyRound1).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound1).Returns(true).Once()
+	m.Mock(newRound).Expects(TriggerSourceScheduled, "", dummyQueued[1]).Returns(dummyRound2).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound2).Returns(false).Once()
+	m.Mock(dispatchRound).Expects(dummyApplication, dummyRound2).Returns().Once()
+
+	// SUT + act
+	resumeApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.False(t, dummyApplication.paused)
+	assert.Empty(t, dummyApplication.queued)
+}
+
+func TestDropPausedRounds_NoneQueued(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+
+	// SUT + act
+	dropPausedRounds(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.queued)
+}
+
+func TestDropPausedRounds_Queued(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		queued:  []time.Time{time.Now().Add(-2 * time.Minute), time.Now().Add(-time.Minute)},
 	}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(handleSession).Expects(dummyApplication, gomocker.Matches(callChecker), 1).Returns(dummyErrors[0]).Once()
-	m.Mock(handleSession).Expects(dummyApplication, gomocker.Matches(callChecker), 1).Returns(dummyErrors[1]).Once()
-	m.Mock(handleSession).Expects(dummyApplication, gomocker.Matches(callChecker), 1).Returns(dummyErrors[2]).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "dropPausedRounds",
+		"Scheduling stopped, [%v] round(s) queued while paused dropped", 2).Returns().Once()
 
 	// SUT + act
-	runInstances(
+	dropPausedRounds(
 		dummyApplication,
 	)
 
 	// assert
-	assert.Len(t, dummyApplication.lastErrors, 3)
-	assert.ElementsMatch(t, dummyErrors, dummyApplication.lastErrors)
+	assert.Empty(t, dummyApplication.queued)
 }
 
-func TestRunInstances_Overlap(t *testing.T) {
+func TestScheduleExecution_HappyPath(t *testing.T) {
 	// arrange
-	var dummyReruns = rand.Int32N(65535)
 	var dummyApplication = &application{
-		instances: 1,
-		reruns:    []int32{dummyReruns},
-		overlap:   true,
+		name:    "some name",
+		started: true,
+		overlap: OverlapPolicy(rand.IntN(4)),
 	}
-	var dummyError = errors.New("some error")
-
-	// stub
-	dummyApplication.waits.Add(1)
+	var dummyRound = &round{}
+	var dummyTimeNext = time.Now()
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(handleSession).Expects(dummyApplication, 0, int(dummyReruns)+1).Returns(dummyError).Once()
+	m.Mock(catchUpRounds).Expects(dummyApplication).Returns().Once()
+	m.Mock(waitForNextRun).Expects(dummyApplication).Returns(dummyTimeNext, true).Once()
+	m.Mock(holdPausedRound).Expects(dummyApplication, dummyTimeNext).Returns(false).Once()
+	m.Mock(newRound).Expects(TriggerSourceScheduled, "", dummyTimeNext).Returns(dummyRound).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound).Returns(false).Once()
+	m.Mock(dispatchRound).Expects(dummyApplication, dummyRound).Returns().Once()
+	m.Mock(waitForNextRun).Expects(dummyApplication).Returns(time.Time{}, false).Once()
+	m.Mock(dropPausedRounds).Expects(dummyApplication).Returns().Once()
 
 	// SUT + act
-	runInstances(
+	scheduleExecution(
 		dummyApplication,
 	)
-
-	// assert
-	assert.Len(t, dummyApplication.lastErrors, 1)
-	assert.Equal(t, dummyError, dummyApplication.lastErrors[0])
 }
 
-func TestScheduleExecution_WithOverlap(t *testing.T) {
+func TestScheduleExecution_Tripped(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
 		name:    "some name",
 		started: true,
-		overlap: true,
+		overlap: OverlapPolicy(rand.IntN(4)),
 	}
+	var dummyRound = &round{}
+	var dummyTimeNext = time.Now()
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(waitForNextRun).Expects(dummyApplication).Returns().Once()
-	m.Mock(runInstances).Expects(dummyApplication).Returns().SideEffects(
-		gomocker.GeneralSideEffect(0, func() { dummyApplication.waits.Done() })).Once()
-	m.Mock(waitForNextRun).Expects(dummyApplication).Returns().SideEffects(
-		gomocker.GeneralSideEffect(0, func() { dummyApplication.started = false })).Once()
+	m.Mock(waitForNextRun).Expects(dummyApplication).Returns(dummyTimeNext, true).Once()
+	m.Mock(holdPausedRound).Expects(dummyApplication, dummyTimeNext).Returns(false).Once()
+	m.Mock(newRound).Expects(TriggerSourceScheduled, "", dummyTimeNext).Returns(dummyRound).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound).Returns(true).Once()
+	m.Mock(waitForNextRun).Expects(dummyApplication).Returns(time.Time{}, false).Once()
+	m.Mock(dropPausedRounds).Expects(dummyApplication).Returns().Once()
 
 	// SUT + act
 	scheduleExecution(
@@ -634,22 +2749,23 @@ func TestScheduleExecution_WithOverlap(t *testing.T) {
 	)
 }
 
-func TestScheduleExecution_NoOverlap(t *testing.T) {
+func TestScheduleExecution_Paused(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
 		name:    "some name",
 		started: true,
-		overlap: false,
+		overlap: OverlapPolicy(rand.IntN(4)),
 	}
+	var dummyTimeNext = time.Now()
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(waitForNextRun).Expects(dummyApplication).Returns().Once()
-	m.Mock(runInstances).Expects(dummyApplication).Returns().Once()
-	m.Mock(waitForNextRun).Expects(dummyApplication).Returns().SideEffects(
-		gomocker.GeneralSideEffect(0, func() { dummyApplication.started = false })).Once()
+	m.Mock(waitForNextRun).Expects(dummyApplication).Returns(dummyTimeNext, true).Once()
+	m.Mock(holdPausedRound).Expects(dummyApplication, dummyTimeNext).Returns(true).Once()
+	m.Mock(waitForNextRun).Expects(dummyApplication).Returns(time.Time{}, false).Once()
+	m.Mock(dropPausedRounds).Expects(dummyApplication).Returns().Once()
 
 	// SUT + act
 	scheduleExecution(
@@ -668,14 +2784,67 @@ func TestRunApplication_NoSchedule(t *testing.T) {
 		name:     "some name",
 		shutdown: dummyShutdown,
 		schedule: dummySchedule,
+		ctx:      context.Background(),
 	}
+	var dummyRound = &round{}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
 	m.Mock(isInterfaceValueNil).Expects(dummySchedule).Returns(true).Once()
-	m.Mock(runInstances).Expects(dummyApplication).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceScheduled, "", gomocker.Anything()).Returns(dummyRound).Once()
+	m.Mock(dispatchRound).Expects(dummyApplication, dummyRound).Returns().Once()
+
+	// SUT + act
+	go runApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.True(t, <-dummyShutdown)
+}
+
+func TestRunApplication_WithJobs(t *testing.T) {
+	// arrange
+	var dummyShutdown = make(chan bool)
+	var dummyApplication = &application{
+		name:     "some name",
+		shutdown: dummyShutdown,
+		jobs:     []*application{},
+		ctx:      context.Background(),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runJobs).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	go runApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.True(t, <-dummyShutdown)
+}
+
+func TestRunApplication_Dependent(t *testing.T) {
+	// arrange
+	var dummyShutdown = make(chan bool)
+	var dummyApplication = &application{
+		name:     "some name",
+		shutdown: dummyShutdown,
+		arrived:  make(chan struct{}, 1),
+		ctx:      context.Background(),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(awaitUpstreams).Expects(dummyApplication).Returns().Once()
 
 	// SUT + act
 	go runApplication(
@@ -697,6 +2866,7 @@ func TestRunApplication_WithSchedule(t *testing.T) {
 		name:     "some name",
 		shutdown: dummyShutdown,
 		schedule: dummySchedule,
+		ctx:      context.Background(),
 	}
 
 	// mock
@@ -715,12 +2885,45 @@ func TestRunApplication_WithSchedule(t *testing.T) {
 	assert.True(t, <-dummyShutdown)
 }
 
+func TestRunApplication_ContextCancelled(t *testing.T) {
+	// arrange
+	var dummyShutdown = make(chan bool)
+	type schedule struct {
+		Schedule
+	}
+	var dummySchedule = &schedule{}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyApplication = &application{
+		name:     "some name",
+		shutdown: dummyShutdown,
+		schedule: dummySchedule,
+		ctx:      dummyContext,
+	}
+
+	// stub
+	dummyCancel()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isInterfaceValueNil).Expects(dummySchedule).Returns(false).Once()
+	m.Mock(scheduleExecution).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	runApplication(
+		dummyApplication,
+	)
+}
+
 func TestBeginApplication_HappyPath(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
 	var dummyVersion = "some version"
 	var dummySession = &session{id: uuid.New()}
 	var dummyShutdown = make(chan bool)
+	var dummySignals = make(chan os.Signal)
+	var dummyHandled = make(chan struct{})
 	var dummyApplication = &application{
 		name:     dummyName,
 		version:  dummyVersion,
@@ -732,6 +2935,9 @@ func TestBeginApplication_HappyPath(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(trapSignals).Expects(dummyApplication).Returns(dummySignals).Once()
+	m.Mock(handleSignals).Expects(dummyApplication, dummySignals).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyHandled) })).Once()
 	m.Mock(runApplication).Expects(dummyApplication).Returns().SideEffects(
 		gomocker.GeneralSideEffect(1, func() { dummyShutdown <- true })).Once()
 	m.Mock(logAppRoot).Expects(dummySession, "application", "beginApplication",
@@ -741,10 +2947,107 @@ func TestBeginApplication_HappyPath(t *testing.T) {
 	// SUT + act
 	beginApplication(
 		dummyApplication,
+		context.Background(),
+	)
+
+	// assert
+	<-dummyHandled
+	assert.False(t, dummyApplication.started)
+	assert.Error(t, dummyApplication.ctx.Err())
+	assert.Error(t, dummyApplication.scheduling.Err())
+	var _, ok = <-dummyApplication.terminated
+	assert.False(t, ok)
+}
+
+func TestBeginApplication_Hosted(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyVersion = "some version"
+	var dummySession = &session{id: uuid.New()}
+	var dummyShutdown = make(chan bool)
+	var dummyHandled = make(chan struct{})
+	var dummyHostScheduling, dummyHostHalt = context.WithCancel(context.Background())
+	var dummyApplication = &application{
+		name:     dummyName,
+		version:  dummyVersion,
+		session:  dummySession,
+		shutdown: dummyShutdown,
+		host: &application{
+			scheduling: dummyHostScheduling,
+		},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(trapSignals).Expects(dummyApplication).Returns(nil).Once()
+	m.Mock(handleSignals).Expects(dummyApplication, nil).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyHandled) })).Once()
+	m.Mock(runApplication).Expects(dummyApplication).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() {
+			dummyHostHalt()
+			<-dummyApplication.scheduling.Done()
+			dummyShutdown <- true
+		})).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "beginApplication",
+		"Trying to start runner [%v] (v-%v)", dummyName, dummyVersion).Returns().Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "beginApplication", "Runner terminated").Returns().Once()
+
+	// SUT + act
+	beginApplication(
+		dummyApplication,
+		context.Background(),
+	)
+
+	// assert
+	<-dummyHandled
+	assert.False(t, dummyApplication.started)
+	assert.Error(t, dummyApplication.scheduling.Err())
+}
+
+func TestBeginApplication_ContextCancelled(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyVersion = "some version"
+	var dummySession = &session{id: uuid.New()}
+	var dummyShutdown = make(chan bool)
+	var dummySignals = make(chan os.Signal)
+	var dummyHandled = make(chan struct{})
+	var dummyApplication = &application{
+		name:     dummyName,
+		version:  dummyVersion,
+		session:  dummySession,
+		shutdown: dummyShutdown,
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(trapSignals).Expects(dummyApplication).Returns(dummySignals).Once()
+	m.Mock(handleSignals).Expects(dummyApplication, dummySignals).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyHandled) })).Once()
+	m.Mock(runApplication).Expects(dummyApplication).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() { dummyCancel() })).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "beginApplication",
+		"Trying to start runner [%v] (v-%v)", dummyName, dummyVersion).Returns().Once()
+	m.Mock(logAppRoot).Expects(dummySession, "application", "beginApplication", "Runner terminated").Returns().Once()
+
+	// SUT + act
+	beginApplication(
+		dummyApplication,
+		dummyContext,
 	)
 
 	// assert
+	<-dummyHandled
 	assert.False(t, dummyApplication.started)
+	assert.Error(t, dummyApplication.ctx.Err())
+	assert.Error(t, dummyApplication.scheduling.Err())
+	var _, ok = <-dummyApplication.terminated
+	assert.False(t, ok)
 }
 
 func TestEndApplication_Error(t *testing.T) {
@@ -757,6 +3060,7 @@ func TestEndApplication_Error(t *testing.T) {
 	}
 	var dummyCustomization = &customization{}
 	var dummyApplication = &application{
+		errors:        newErrorRing(10),
 		session:       dummySession,
 		customization: dummyCustomization,
 	}
@@ -777,8 +3081,11 @@ func TestEndApplication_Error(t *testing.T) {
 	)
 
 	// assert
-	assert.Len(t, dummyApplication.lastErrors, 1)
-	assert.Equal(t, dummyError, dummyApplication.lastErrors[0])
+	var runErrors = dummyApplication.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, -1, runErrors[0].Index)
+	assert.Equal(t, RunPhaseAppClosing, runErrors[0].Phase)
+	assert.Equal(t, dummyError, runErrors[0].Err)
 }
 
 func TestEndApplication_Success(t *testing.T) {
@@ -791,6 +3098,7 @@ func TestEndApplication_Success(t *testing.T) {
 	}
 	var dummyCustomization = &customization{}
 	var dummyApplication = &application{
+		errors:        newErrorRing(10),
 		session:       dummySession,
 		customization: dummyCustomization,
 	}
@@ -810,5 +3118,223 @@ func TestEndApplication_Success(t *testing.T) {
 	)
 
 	// assert
-	assert.Empty(t, dummyApplication.lastErrors)
+	assert.Empty(t, dummyApplication.RunErrors())
+}
+
+func TestRegisterSession(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		inflight: map[uuid.UUID]*session{},
+	}
+
+	// SUT + act
+	registerSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Len(t, dummyApplication.inflight, 1)
+	assert.Equal(t, dummySession, dummyApplication.inflight[dummySession.id])
+}
+
+func TestUnregisterSession(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		inflight: map[uuid.UUID]*session{
+			dummySession.id: dummySession,
+		},
+	}
+
+	// SUT + act
+	unregisterSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.inflight)
+}
+
+func TestTerminateInstances_NoInflight(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		errors:   newErrorRing(10),
+		inflight: map[uuid.UUID]*session{},
+	}
+
+	// SUT + act
+	terminateInstances(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.RunErrors())
+}
+
+func TestTerminateInstances_WithInflight(t *testing.T) {
+	// arrange
+	var dummyRootSession = &session{id: uuid.New()}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{
+		id:     uuid.New(),
+		index:  rand.IntN(100),
+		reruns: rand.IntN(100),
+		round:  dummyRound,
+	}
+	var dummyApplication = &application{
+		session: dummyRootSession,
+		errors:  newErrorRing(10),
+		inflight: map[uuid.UUID]*session{
+			dummySession.id: dummySession,
+		},
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(fmt.Errorf).Expects("Instance [%v] (rerun [%v]) of session [%v] force-terminated after graceful shutdown timeout",
+		dummySession.index, dummySession.reruns, dummySession.id).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummyRootSession, "application", "terminateInstances", "%v", dummyError).Returns().Once()
+
+	// SUT + act
+	terminateInstances(
+		dummyApplication,
+	)
+
+	// assert
+	var runErrors = dummyApplication.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, dummyRound.id, runErrors[0].RoundID)
+	assert.Equal(t, dummySession.index, runErrors[0].Index)
+	assert.Equal(t, dummySession.reruns, runErrors[0].Reruns)
+	assert.Equal(t, RunPhaseTerminate, runErrors[0].Phase)
+	assert.Equal(t, dummyError, runErrors[0].Err)
+	assert.False(t, dummyRound.failed.Load())
+}
+
+func TestTerminateInstances_WithJobs(t *testing.T) {
+	// arrange
+	var dummyJobSession = &session{id: uuid.New()}
+	var dummySession = &session{
+		id:     uuid.New(),
+		index:  rand.IntN(100),
+		reruns: rand.IntN(100),
+	}
+	var dummyJob = &application{
+		session: dummyJobSession,
+		errors:  newErrorRing(10),
+		inflight: map[uuid.UUID]*session{
+			dummySession.id: dummySession,
+		},
+	}
+	var dummyApplication = &application{
+		session:  &session{id: uuid.New()},
+		errors:   newErrorRing(10),
+		inflight: map[uuid.UUID]*session{},
+		jobs:     []*application{dummyJob},
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(fmt.Errorf).Expects("Instance [%v] (rerun [%v]) of session [%v] force-terminated after graceful shutdown timeout",
+		dummySession.index, dummySession.reruns, dummySession.id).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummyJobSession, "application", "terminateInstances", "%v", dummyError).Returns().Once()
+
+	// SUT + act
+	terminateInstances(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.RunErrors())
+	var runErrors = dummyJob.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, RunPhaseTerminate, runErrors[0].Phase)
+	assert.Equal(t, dummyError, runErrors[0].Err)
+}
+
+func TestStopGracefully_Drained(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	var dummyTerminated = make(chan struct{})
+	var dummyApplication = &application{
+		session:    dummySession,
+		scheduling: dummyScheduling,
+		halt:       dummyHalt,
+		terminated: dummyTerminated,
+	}
+	var dummyTimeout = time.Duration(rand.IntN(1000))
+
+	// stub
+	close(dummyTerminated)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "stopGracefully",
+		"Halting scheduling and waiting up to [%v] for running instances to complete", dummyTimeout).Returns().Once()
+	m.Mock(time.After).Expects(dummyTimeout).Returns(make(chan time.Time)).Once()
+
+	// SUT + act
+	stopGracefully(
+		dummyApplication,
+		dummyTimeout,
+	)
+
+	// assert
+	assert.Error(t, dummyScheduling.Err())
+}
+
+func TestStopGracefully_Timeout(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyScheduling, dummyHalt = context.WithCancel(dummyContext)
+	var dummyTerminated = make(chan struct{})
+	var dummyApplication = &application{
+		session:    dummySession,
+		ctx:        dummyContext,
+		cancel:     dummyCancel,
+		scheduling: dummyScheduling,
+		halt:       dummyHalt,
+		terminated: dummyTerminated,
+	}
+	var dummyTimeout = time.Duration(rand.IntN(1000))
+	var dummyTimer = make(chan time.Time, 1)
+
+	// stub
+	dummyTimer <- time.Now()
+	go func() {
+		<-dummyContext.Done()
+		close(dummyTerminated)
+	}()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "application", "stopGracefully",
+		"Halting scheduling and waiting up to [%v] for running instances to complete", dummyTimeout).Returns().Once()
+	m.Mock(time.After).Expects(dummyTimeout).Returns(dummyTimer).Once()
+	m.Mock(terminateInstances).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	stopGracefully(
+		dummyApplication,
+		dummyTimeout,
+	)
+
+	// assert
+	assert.Error(t, dummyScheduling.Err())
+	assert.Error(t, dummyContext.Err())
 }
@@ -0,0 +1,59 @@
+package jobrunner
+
+// BreakerState is the state of the circuit breaker guarding the scheduled rounds against consecutive failures
+type BreakerState int
+
+// These are the enum definitions of breaker states
+const (
+	BreakerStateClosed BreakerState = iota
+	BreakerStateOpen
+	BreakerStateHalfOpen
+)
+
+// These are the string representations of breaker states
+const (
+	closedBreakerStateName   string = "Closed"
+	openBreakerStateName     string = "Open"
+	halfOpenBreakerStateName string = "HalfOpen"
+)
+
+var supportedBreakerStates = map[BreakerState]string{
+	BreakerStateClosed:   closedBreakerStateName,
+	BreakerStateOpen:     openBreakerStateName,
+	BreakerStateHalfOpen: halfOpenBreakerStateName,
+}
+
+var breakerStateNameMapping = map[string]BreakerState{
+	closedBreakerStateName:   BreakerStateClosed,
+	openBreakerStateName:     BreakerStateOpen,
+	halfOpenBreakerStateName: BreakerStateHalfOpen,
+}
+
+// String converts a BreakerState instance to its string representation
+func (breakerState BreakerState) String() string {
+	var name, found = supportedBreakerStates[breakerState]
+	if !found {
+		return closedBreakerStateName
+	}
+	return name
+}
+
+// NewBreakerState converts a string representation of BreakerState to its strongly typed instance
+func NewBreakerState(value string) BreakerState {
+	var breakerState, found = breakerStateNameMapping[value]
+	if !found {
+		return BreakerStateClosed
+	}
+	return breakerState
+}
+
+// MarshalText converts a BreakerState instance to its string representation for encodings like JSON
+func (breakerState BreakerState) MarshalText() ([]byte, error) {
+	return []byte(breakerState.String()), nil
+}
+
+// UnmarshalText converts a string representation of BreakerState from encodings like JSON to its strongly typed instance
+func (breakerState *BreakerState) UnmarshalText(text []byte) error {
+	*breakerState = NewBreakerState(string(text))
+	return nil
+}
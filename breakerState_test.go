@@ -0,0 +1,74 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakerStateString_NonSupportedBreakerState(t *testing.T) {
+	// SUT
+	var sut = BreakerState(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, closedBreakerStateName, result)
+}
+
+func TestBreakerStateString_SupportedBreakerState(t *testing.T) {
+	// SUT
+	var sut = BreakerStateHalfOpen
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, halfOpenBreakerStateName, result)
+}
+
+func TestNewBreakerState_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewBreakerState(dummyValue)
+
+	// assert
+	assert.Equal(t, BreakerStateClosed, result)
+}
+
+func TestNewBreakerState_HappyPath(t *testing.T) {
+	for key, value := range breakerStateNameMapping {
+		// SUT + act
+		var result = NewBreakerState(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
+
+func TestBreakerStateMarshalText(t *testing.T) {
+	// SUT
+	var sut = BreakerStateHalfOpen
+
+	// act
+	var result, err = sut.MarshalText()
+
+	// assert
+	assert.Equal(t, []byte("HalfOpen"), result)
+	assert.NoError(t, err)
+}
+
+func TestBreakerStateUnmarshalText(t *testing.T) {
+	// SUT
+	var sut BreakerState
+
+	// act
+	var err = sut.UnmarshalText([]byte("Open"))
+
+	// assert
+	assert.Equal(t, BreakerStateOpen, sut)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,176 @@
+package jobrunner
+
+import (
+	"time"
+)
+
+// CatchUpPolicy is the policy of catching up the scheduled slots missed while the application was down, e.g. across restarts
+type CatchUpPolicy struct {
+	// Mode is how the missed slots are caught up upon start, i.e. not at all, once for the latest missed slot, or for every missed slot
+	Mode CatchUpMode
+	// MaxRuns caps how many of the latest missed slots are caught up in the all mode; 0 or negative means no cap
+	MaxRuns int
+	// Store is the store persisting the last completed scheduled slot, e.g. NewFileSlotStore; nil means no catch-up
+	Store SlotStore
+}
+
+// getMissedSlots returns the slots of the built-in schedule after the given last completed slot and before the next slot of the schedule, keeping only the given number of the latest ones unless the limit is 0 or negative; the total number of missed slots is returned as well
+func getMissedSlots(app *application, last time.Time, limit int) ([]time.Time, int) {
+	var template, ok = app.schedule.(*schedule)
+	if !ok {
+		logAppRoot(
+			app.session,
+			"catchUp",
+			"getMissedSlots",
+			"Catch-up is only supported for schedules made by NewScheduleMaker, skipping catch-up for schedule of type [%T]",
+			app.schedule,
+		)
+		return nil, 0
+	}
+	var next = constructTimeBySchedule(
+		template,
+	)
+	var replay = *template
+	replay.completed = false
+	var initialiseError = initialiseSchedule(
+		last.Add(time.Second).In(replay.timezone),
+		&replay,
+	)
+	if initialiseError != nil {
+		return nil, 0
+	}
+	var slots = []time.Time{}
+	var missed = 0
+	for !replay.completed {
+		var slot = constructTimeBySchedule(
+			&replay,
+		)
+		if !slot.Before(next) ||
+			(replay.till != nil && replay.till.Before(slot)) {
+			break
+		}
+		missed++
+		slots = append(
+			slots,
+			slot,
+		)
+		if limit > 0 &&
+			len(slots) > limit {
+			slots = slots[1:]
+		}
+		replay.completed = updateScheduleIndex(
+			&replay,
+		)
+	}
+	return slots, missed
+}
+
+// runCatchUpRound executes the round catching up a missed slot, and blocks until the round completes
+func runCatchUpRound(app *application, round *round) {
+	app.lock.Lock()
+	var previous = registerRound(
+		app,
+		round,
+	)
+	app.lock.Unlock()
+	for _, running := range previous {
+		cancelRound(
+			app,
+			running,
+		)
+	}
+	app.waits.Add(1)
+	completeRound(
+		app,
+		round,
+	)
+}
+
+// catchUpRounds executes the rounds of the scheduled slots missed since the last completed slot one after another according to the catch-up policy, before any further scheduled round
+func catchUpRounds(app *application) {
+	if isInterfaceValueNil(app.catchUp.Store) ||
+		app.catchUp.Mode == CatchUpModeNone {
+		return
+	}
+	var last, loadError = app.catchUp.Store.LoadSlot(
+		app.name,
+	)
+	if loadError != nil {
+		logAppRoot(
+			app.session,
+			"catchUp",
+			"catchUpRounds",
+			"Failed to load the last completed slot, skipping catch-up. Error: %+v",
+			loadError,
+		)
+		return
+	}
+	if last.IsZero() {
+		return
+	}
+	var limit = app.catchUp.MaxRuns
+	if app.catchUp.Mode == CatchUpModeOnce {
+		limit = 1
+	}
+	var slots, missed = getMissedSlots(
+		app,
+		last,
+		limit,
+	)
+	if missed == 0 {
+		return
+	}
+	logAppRoot(
+		app.session,
+		"catchUp",
+		"catchUpRounds",
+		"Catching up [%v] of [%v] slot(s) missed since [%v] by mode [%v]",
+		len(slots),
+		missed,
+		last,
+		app.catchUp.Mode,
+	)
+	for _, slot := range slots {
+		if app.scheduling.Err() != nil {
+			return
+		}
+		var round = newRound(
+			TriggerSourceCatchUp,
+			"",
+			slot,
+		)
+		if holdTrippedRound(
+			app,
+			round,
+		) {
+			continue
+		}
+		runCatchUpRound(
+			app,
+			round,
+		)
+	}
+}
+
+// saveSlot persists the scheduled slot of the completed round as the last completed one, including the rounds executed by another replica; rounds not triggered by the schedule are ignored
+func saveSlot(app *application, record *RoundRecord) {
+	if isInterfaceValueNil(app.catchUp.Store) ||
+		(record.Trigger != TriggerSourceScheduled && record.Trigger != TriggerSourceCatchUp) ||
+		(record.Status != RoundStatusCompleted && record.Status != RoundStatusLocked) {
+		return
+	}
+	var saveError = app.catchUp.Store.SaveSlot(
+		app.name,
+		record.ScheduledTime,
+	)
+	if saveError != nil {
+		logAppRoot(
+			app.session,
+			"catchUp",
+			"saveSlot",
+			"Failed to save the last completed slot [%v]. Error: %+v",
+			record.ScheduledTime,
+			saveError,
+		)
+	}
+}
@@ -0,0 +1,48 @@
+package jobrunner
+
+// CatchUpMode is the mode of catching up the scheduled slots missed while the application was down
+type CatchUpMode int
+
+// These are the enum definitions of catch-up modes
+const (
+	CatchUpModeNone CatchUpMode = iota
+	CatchUpModeOnce
+	CatchUpModeAll
+)
+
+// These are the string representations of catch-up modes
+const (
+	noneCatchUpModeName string = "None"
+	onceCatchUpModeName string = "Once"
+	allCatchUpModeName  string = "All"
+)
+
+var supportedCatchUpModes = map[CatchUpMode]string{
+	CatchUpModeNone: noneCatchUpModeName,
+	CatchUpModeOnce: onceCatchUpModeName,
+	CatchUpModeAll:  allCatchUpModeName,
+}
+
+var catchUpModeNameMapping = map[string]CatchUpMode{
+	noneCatchUpModeName: CatchUpModeNone,
+	onceCatchUpModeName: CatchUpModeOnce,
+	allCatchUpModeName:  CatchUpModeAll,
+}
+
+// String converts a CatchUpMode instance to its string representation
+func (catchUpMode CatchUpMode) String() string {
+	var name, found = supportedCatchUpModes[catchUpMode]
+	if !found {
+		return noneCatchUpModeName
+	}
+	return name
+}
+
+// NewCatchUpMode converts a string representation of CatchUpMode to its strongly typed instance
+func NewCatchUpMode(value string) CatchUpMode {
+	var catchUpMode, found = catchUpModeNameMapping[value]
+	if !found {
+		return CatchUpModeNone
+	}
+	return catchUpMode
+}
@@ -0,0 +1,50 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatchUpModeString_NonSupportedCatchUpMode(t *testing.T) {
+	// SUT
+	var sut = CatchUpMode(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, noneCatchUpModeName, result)
+}
+
+func TestCatchUpModeString_SupportedCatchUpMode(t *testing.T) {
+	// SUT
+	var sut = CatchUpModeAll
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, allCatchUpModeName, result)
+}
+
+func TestNewCatchUpMode_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewCatchUpMode(dummyValue)
+
+	// assert
+	assert.Equal(t, CatchUpModeNone, result)
+}
+
+func TestNewCatchUpMode_HappyPath(t *testing.T) {
+	for key, value := range catchUpModeNameMapping {
+		// SUT + act
+		var result = NewCatchUpMode(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
@@ -0,0 +1,532 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestGetMissedSlots_NotSchedule(t *testing.T) {
+	// arrange
+	type customSchedule struct {
+		Schedule
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummySchedule = &customSchedule{}
+	var dummyApplication = &application{
+		session:  dummySession,
+		schedule: dummySchedule,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "catchUp", "getMissedSlots",
+		"Catch-up is only supported for schedules made by NewScheduleMaker, skipping catch-up for schedule of type [%T]",
+		dummySchedule).Returns().Once()
+
+	// SUT + act
+	var slots, missed = getMissedSlots(
+		dummyApplication,
+		time.Now(),
+		0,
+	)
+
+	// assert
+	assert.Nil(t, slots)
+	assert.Zero(t, missed)
+}
+
+func TestGetMissedSlots_InitialiseError(t *testing.T) {
+	// arrange
+	var dummySchedule, _ = NewScheduleMaker().OnSeconds(0).InYears(2030).From(
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local),
+	).Schedule()
+	var dummyApplication = &application{
+		schedule: dummySchedule,
+	}
+
+	// SUT + act
+	var slots, missed = getMissedSlots(
+		dummyApplication,
+		time.Date(2031, 1, 1, 0, 0, 0, 0, time.Local),
+		0,
+	)
+
+	// assert
+	assert.Nil(t, slots)
+	assert.Zero(t, missed)
+}
+
+func TestGetMissedSlots_NoneMissed(t *testing.T) {
+	// arrange
+	var dummySchedule, _ = NewScheduleMaker().OnSeconds(0, 10, 20, 30, 40, 50).From(
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local),
+	).Schedule()
+	var dummyApplication = &application{
+		schedule: dummySchedule,
+	}
+
+	// SUT + act
+	var slots, missed = getMissedSlots(
+		dummyApplication,
+		time.Date(2029, 12, 31, 23, 59, 55, 0, time.Local),
+		0,
+	)
+
+	// assert
+	assert.Empty(t, slots)
+	assert.Zero(t, missed)
+}
+
+func TestGetMissedSlots_AllMissed(t *testing.T) {
+	// arrange
+	var dummySchedule, _ = NewScheduleMaker().OnSeconds(0, 10, 20, 30, 40, 50).From(
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local),
+	).Schedule()
+	var dummyApplication = &application{
+		schedule: dummySchedule,
+	}
+
+	// SUT + act
+	var slots, missed = getMissedSlots(
+		dummyApplication,
+		time.Date(2029, 12, 31, 23, 59, 10, 0, time.Local),
+		0,
+	)
+
+	// assert
+	assert.Equal(t, []time.Time{
+		time.Date(2029, 12, 31, 23, 59, 20, 0, time.Local),
+		time.Date(2029, 12, 31, 23, 59, 30, 0, time.Local),
+		time.Date(2029, 12, 31, 23, 59, 40, 0, time.Local),
+		time.Date(2029, 12, 31, 23, 59, 50, 0, time.Local),
+	}, slots)
+	assert.Equal(t, 4, missed)
+	assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local), constructTimeBySchedule(dummySchedule.(*schedule)))
+}
+
+func TestGetMissedSlots_Limited(t *testing.T) {
+	// arrange
+	var dummySchedule, _ = NewScheduleMaker().OnSeconds(0, 10, 20, 30, 40, 50).From(
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local),
+	).Schedule()
+	var dummyApplication = &application{
+		schedule: dummySchedule,
+	}
+
+	// SUT + act
+	var slots, missed = getMissedSlots(
+		dummyApplication,
+		time.Date(2029, 12, 31, 23, 59, 10, 0, time.Local),
+		2,
+	)
+
+	// assert
+	assert.Equal(t, []time.Time{
+		time.Date(2029, 12, 31, 23, 59, 40, 0, time.Local),
+		time.Date(2029, 12, 31, 23, 59, 50, 0, time.Local),
+	}, slots)
+	assert.Equal(t, 4, missed)
+}
+
+func TestGetMissedSlots_Till(t *testing.T) {
+	// arrange
+	var dummySchedule, _ = NewScheduleMaker().OnSeconds(0, 10, 20, 30, 40, 50).From(
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local),
+	).Till(
+		time.Date(2029, 12, 31, 23, 59, 35, 0, time.Local),
+	).Schedule()
+	var dummyApplication = &application{
+		schedule: dummySchedule,
+	}
+
+	// SUT + act
+	var slots, missed = getMissedSlots(
+		dummyApplication,
+		time.Date(2029, 12, 31, 23, 59, 10, 0, time.Local),
+		0,
+	)
+
+	// assert
+	assert.Equal(t, []time.Time{
+		time.Date(2029, 12, 31, 23, 59, 20, 0, time.Local),
+		time.Date(2029, 12, 31, 23, 59, 30, 0, time.Local),
+	}, slots)
+	assert.Equal(t, 2, missed)
+}
+
+func TestRunCatchUpRound(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		ctx:     context.Background(),
+		overlap: OverlapPolicyCancelPrevious,
+		rounds: map[uuid.UUID]*round{
+			dummyRunning.id: dummyRunning,
+		},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(cancelRound).Expects(dummyApplication, dummyRunning).Returns().Once()
+	m.Mock(completeRound).Expects(dummyApplication, dummyRound).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() {
+			dummyApplication.waits.Done()
+		}),
+	).Once()
+
+	// SUT + act
+	runCatchUpRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummyRound, dummyApplication.rounds[dummyRound.id])
+	assert.NotNil(t, dummyRound.ctx)
+	dummyApplication.waits.Wait()
+}
+
+func TestCatchUpRounds_NoStore(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		catchUp: CatchUpPolicy{Mode: CatchUpModeAll},
+	}
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_ModeNone(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummyApplication = &application{
+		catchUp: CatchUpPolicy{Mode: CatchUpModeNone, Store: &slotStore{}},
+	}
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_LoadError(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyStore = &slotStore{}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		catchUp: CatchUpPolicy{Mode: CatchUpModeAll, Store: dummyStore},
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).LoadSlot).Expects(dummyStore, "some name").Returns(time.Time{}, dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "catchUp", "catchUpRounds",
+		"Failed to load the last completed slot, skipping catch-up. Error: %+v", dummyError).Returns().Once()
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_NoSlot(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummyStore = &slotStore{}
+	var dummyApplication = &application{
+		name:    "some name",
+		catchUp: CatchUpPolicy{Mode: CatchUpModeAll, Store: dummyStore},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).LoadSlot).Expects(dummyStore, "some name").Returns(time.Time{}, nil).Once()
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_NoneMissed(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummyStore = &slotStore{}
+	var dummyMaxRuns = rand.IntN(100)
+	var dummyApplication = &application{
+		name:    "some name",
+		catchUp: CatchUpPolicy{Mode: CatchUpModeAll, MaxRuns: dummyMaxRuns, Store: dummyStore},
+	}
+	var dummyLast = time.Now().Add(-time.Hour)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).LoadSlot).Expects(dummyStore, "some name").Returns(dummyLast, nil).Once()
+	m.Mock(getMissedSlots).Expects(dummyApplication, dummyLast, dummyMaxRuns).Returns(nil, 0).Once()
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_Once(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyStore = &slotStore{}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		scheduling: context.Background(),
+		catchUp:    CatchUpPolicy{Mode: CatchUpModeOnce, MaxRuns: 5, Store: dummyStore},
+	}
+	var dummyLast = time.Now().Add(-time.Hour)
+	var dummySlot = time.Now().Add(-time.Minute)
+	var dummyMissed = rand.IntN(100) + 1
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).LoadSlot).Expects(dummyStore, "some name").Returns(dummyLast, nil).Once()
+	m.Mock(getMissedSlots).Expects(dummyApplication, dummyLast, 1).Returns([]time.Time{dummySlot}, dummyMissed).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "catchUp", "catchUpRounds",
+		"Catching up [%v] of [%v] slot(s) missed since [%v] by mode [%v]",
+		1, dummyMissed, dummyLast, CatchUpModeOnce).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceCatchUp, "", dummySlot).Returns(dummyRound).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound).Returns(false).Once()
+	m.Mock(runCatchUpRound).Expects(dummyApplication, dummyRound).Returns().Once()
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_All(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyStore = &slotStore{}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		scheduling: context.Background(),
+		catchUp:    CatchUpPolicy{Mode: CatchUpModeAll, Store: dummyStore},
+	}
+	var dummyLast = time.Now().Add(-time.Hour)
+	var dummySlot1 = time.Now().Add(-2 * time.Minute)
+	var dummySlot2 = time.Now().Add(-time.Minute)
+	var dummyRound1 = &round{id: uuid.New()}
+	var dummyRound2 = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).LoadSlot).Expects(dummyStore, "some name").Returns(dummyLast, nil).Once()
+	m.Mock(getMissedSlots).Expects(dummyApplication, dummyLast, 0).Returns([]time.Time{dummySlot1, dummySlot2}, 2).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "catchUp", "catchUpRounds",
+		"Catching up [%v] of [%v] slot(s) missed since [%v] by mode [%v]",
+		2, 2, dummyLast, CatchUpModeAll).Returns().Once()
+	m.Mock(newRound).Expects(TriggerSourceCatchUp, "", dummySlot1).Returns(dummyRound1).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound1).Returns(true).Once()
+	m.Mock(newRound).Expects(TriggerSourceCatchUp, "", dummySlot2).Returns(dummyRound2).Once()
+	m.Mock(holdTrippedRound).Expects(dummyApplication, dummyRound2).Returns(false).Once()
+	m.Mock(runCatchUpRound).Expects(dummyApplication, dummyRound2).Returns().Once()
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestCatchUpRounds_Halted(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyStore = &slotStore{}
+	var dummyScheduling, dummyCancel = context.WithCancel(context.Background())
+	dummyCancel()
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		scheduling: dummyScheduling,
+		catchUp:    CatchUpPolicy{Mode: CatchUpModeAll, Store: dummyStore},
+	}
+	var dummyLast = time.Now().Add(-time.Hour)
+	var dummySlot = time.Now().Add(-time.Minute)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).LoadSlot).Expects(dummyStore, "some name").Returns(dummyLast, nil).Once()
+	m.Mock(getMissedSlots).Expects(dummyApplication, dummyLast, 0).Returns([]time.Time{dummySlot}, 1).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "catchUp", "catchUpRounds",
+		"Catching up [%v] of [%v] slot(s) missed since [%v] by mode [%v]",
+		1, 1, dummyLast, CatchUpModeAll).Returns().Once()
+
+	// SUT + act
+	catchUpRounds(
+		dummyApplication,
+	)
+}
+
+func TestSaveSlot_NoStore(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRecord = &RoundRecord{
+		Trigger: TriggerSourceScheduled,
+		Status:  RoundStatusCompleted,
+	}
+
+	// SUT + act
+	saveSlot(
+		dummyApplication,
+		dummyRecord,
+	)
+}
+
+func TestSaveSlot_NotScheduled(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummyApplication = &application{
+		catchUp: CatchUpPolicy{Store: &slotStore{}},
+	}
+	var dummyRecord = &RoundRecord{
+		Trigger: TriggerSourceManual,
+		Status:  RoundStatusCompleted,
+	}
+
+	// SUT + act
+	saveSlot(
+		dummyApplication,
+		dummyRecord,
+	)
+}
+
+func TestSaveSlot_NotCompleted(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummyApplication = &application{
+		catchUp: CatchUpPolicy{Store: &slotStore{}},
+	}
+	var dummyRecord = &RoundRecord{
+		Trigger: TriggerSourceScheduled,
+		Status:  RoundStatusSkipped,
+	}
+
+	// SUT + act
+	saveSlot(
+		dummyApplication,
+		dummyRecord,
+	)
+}
+
+func TestSaveSlot_Error(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyStore = &slotStore{}
+	var dummyApplication = &application{
+		name:    "some name",
+		session: dummySession,
+		catchUp: CatchUpPolicy{Store: dummyStore},
+	}
+	var dummyRecord = &RoundRecord{
+		Trigger:       TriggerSourceScheduled,
+		Status:        RoundStatusCompleted,
+		ScheduledTime: time.Now(),
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).SaveSlot).Expects(dummyStore, "some name", dummyRecord.ScheduledTime).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "catchUp", "saveSlot",
+		"Failed to save the last completed slot [%v]. Error: %+v", dummyRecord.ScheduledTime, dummyError).Returns().Once()
+
+	// SUT + act
+	saveSlot(
+		dummyApplication,
+		dummyRecord,
+	)
+}
+
+func TestSaveSlot_Saved(t *testing.T) {
+	// arrange
+	type slotStore struct {
+		SlotStore
+	}
+	var dummyStore = &slotStore{}
+	var dummyApplication = &application{
+		name:    "some name",
+		catchUp: CatchUpPolicy{Store: dummyStore},
+	}
+	var dummyRecord = &RoundRecord{
+		Trigger:       TriggerSourceCatchUp,
+		Status:        RoundStatusLocked,
+		ScheduledTime: time.Now(),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*slotStore).SaveSlot).Expects(dummyStore, "some name", dummyRecord.ScheduledTime).Returns(nil).Once()
+
+	// SUT + act
+	saveSlot(
+		dummyApplication,
+		dummyRecord,
+	)
+}
@@ -0,0 +1,143 @@
+package jobrunner
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CircuitBreaker is the policy of suspending the scheduled rounds after consecutive failed rounds, e.g. while a dependency is down
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed rounds after which the breaker opens; 0 or negative means no circuit breaker
+	FailureThreshold int
+	// CoolDown is how long the breaker stays open skipping the scheduled rounds, before it turns half-open and lets a single probe round through
+	CoolDown time.Duration
+}
+
+// isRoundAlive returns true if the round with the given ID is still running or waiting to run; the application lock must be held by the caller
+func isRoundAlive(app *application, id uuid.UUID) bool {
+	if _, found := app.rounds[id]; found {
+		return true
+	}
+	if app.pending != nil &&
+		app.pending.id == id {
+		return true
+	}
+	for _, waiting := range app.overflowed {
+		if waiting.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func logBreakerTransition(app *application, from BreakerState, to BreakerState, failures int) {
+	logAppRoot(
+		app.session,
+		"circuitBreaker",
+		"logBreakerTransition",
+		"Circuit breaker transited from [%v] to [%v] with [%v] consecutive failed round(s)",
+		from,
+		to,
+		failures,
+	)
+}
+
+// holdTrippedRound skips the due round while the circuit breaker is open, and lets it through as the probe round once the cool-down has elapsed; returns true if the round is held back
+func holdTrippedRound(app *application, incoming *round) bool {
+	app.lock.Lock()
+	var from = app.breakerState
+	if from == BreakerStateClosed {
+		app.lock.Unlock()
+		return false
+	}
+	if from == BreakerStateOpen &&
+		time.Since(app.openedAt) >= app.breaker.CoolDown {
+		app.breakerState = BreakerStateHalfOpen
+	}
+	var state = app.breakerState
+	var failures = app.failures
+	var probing = isRoundAlive(
+		app,
+		app.probe,
+	)
+	if state == BreakerStateHalfOpen &&
+		!probing {
+		app.probe = incoming.id
+	}
+	app.lock.Unlock()
+	if state != from {
+		logBreakerTransition(
+			app,
+			from,
+			state,
+			failures,
+		)
+	}
+	if state == BreakerStateHalfOpen &&
+		!probing {
+		logAppRoot(
+			app.session,
+			"circuitBreaker",
+			"holdTrippedRound",
+			"Round [%v] let through as the probe by circuit breaker in state [%v]",
+			incoming.id,
+			state,
+		)
+		return false
+	}
+	logAppRoot(
+		app.session,
+		"circuitBreaker",
+		"holdTrippedRound",
+		"Round [%v] skipped by circuit breaker in state [%v] after [%v] consecutive failed round(s)",
+		incoming.id,
+		state,
+		failures,
+	)
+	return true
+}
+
+// recordBreakerResult counts the consecutive failed rounds towards opening or closing the circuit breaker, ignoring cancelled and locked-out rounds
+func recordBreakerResult(app *application, completed *round) {
+	if app.breaker.FailureThreshold <= 0 ||
+		completed.cancelled.Load() ||
+		completed.lockedOut.Load() {
+		return
+	}
+	app.lock.Lock()
+	var from = app.breakerState
+	var to = from
+	var probe = completed.id == app.probe
+	if completed.failed.Load() {
+		app.failures++
+		if from == BreakerStateClosed &&
+			app.failures >= app.breaker.FailureThreshold {
+			to = BreakerStateOpen
+		} else if from == BreakerStateHalfOpen &&
+			probe {
+			to = BreakerStateOpen
+		}
+	} else {
+		app.failures = 0
+		if from == BreakerStateHalfOpen &&
+			probe {
+			to = BreakerStateClosed
+		}
+	}
+	if to != from {
+		app.breakerState = to
+		app.openedAt = time.Now()
+		app.probe = uuid.Nil
+	}
+	var failures = app.failures
+	app.lock.Unlock()
+	if to != from {
+		logBreakerTransition(
+			app,
+			from,
+			to,
+			failures,
+		)
+	}
+}
@@ -0,0 +1,493 @@
+package jobrunner
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestIsRoundAlive_Running(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		rounds: map[uuid.UUID]*round{dummyRound.id: dummyRound},
+	}
+
+	// SUT + act
+	var result = isRoundAlive(
+		dummyApplication,
+		dummyRound.id,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestIsRoundAlive_Pending(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		rounds:  map[uuid.UUID]*round{},
+		pending: dummyRound,
+	}
+
+	// SUT + act
+	var result = isRoundAlive(
+		dummyApplication,
+		dummyRound.id,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestIsRoundAlive_Overflowed(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		rounds:     map[uuid.UUID]*round{},
+		pending:    &round{id: uuid.New()},
+		overflowed: []*round{{id: uuid.New()}, dummyRound},
+	}
+
+	// SUT + act
+	var result = isRoundAlive(
+		dummyApplication,
+		dummyRound.id,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestIsRoundAlive_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		rounds:     map[uuid.UUID]*round{},
+		overflowed: []*round{{id: uuid.New()}},
+	}
+
+	// SUT + act
+	var result = isRoundAlive(
+		dummyApplication,
+		uuid.Nil,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestLogBreakerTransition(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		session: dummySession,
+	}
+	var dummyFailures = rand.IntN(100)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "circuitBreaker", "logBreakerTransition",
+		"Circuit breaker transited from [%v] to [%v] with [%v] consecutive failed round(s)",
+		BreakerStateClosed, BreakerStateOpen, dummyFailures).Returns().Once()
+
+	// SUT + act
+	logBreakerTransition(
+		dummyApplication,
+		BreakerStateClosed,
+		BreakerStateOpen,
+		dummyFailures,
+	)
+}
+
+func TestHoldTrippedRound_Closed(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:         "some name",
+		breakerState: BreakerStateClosed,
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	var result = holdTrippedRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestHoldTrippedRound_CoolingDown(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyFailures = rand.IntN(100)
+	var dummyApplication = &application{
+		name:         "some name",
+		session:      dummySession,
+		breaker:      CircuitBreaker{CoolDown: time.Hour},
+		breakerState: BreakerStateOpen,
+		failures:     dummyFailures,
+		openedAt:     time.Now(),
+		rounds:       map[uuid.UUID]*round{},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "circuitBreaker", "holdTrippedRound",
+		"Round [%v] skipped by circuit breaker in state [%v] after [%v] consecutive failed round(s)",
+		dummyRound.id, BreakerStateOpen, dummyFailures).Returns().Once()
+
+	// SUT + act
+	var result = holdTrippedRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, BreakerStateOpen, dummyApplication.breakerState)
+	assert.Equal(t, uuid.Nil, dummyApplication.probe)
+}
+
+func TestHoldTrippedRound_CoolDownElapsed(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyFailures = rand.IntN(100)
+	var dummyApplication = &application{
+		name:         "some name",
+		session:      dummySession,
+		breaker:      CircuitBreaker{CoolDown: time.Minute},
+		breakerState: BreakerStateOpen,
+		failures:     dummyFailures,
+		openedAt:     time.Now().Add(-time.Hour),
+		rounds:       map[uuid.UUID]*round{},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logBreakerTransition).Expects(dummyApplication, BreakerStateOpen, BreakerStateHalfOpen, dummyFailures).Returns().Once()
+	m.Mock(logAppRoot).Expects(dummySession, "circuitBreaker", "holdTrippedRound",
+		"Round [%v] let through as the probe by circuit breaker in state [%v]",
+		dummyRound.id, BreakerStateHalfOpen).Returns().Once()
+
+	// SUT + act
+	var result = holdTrippedRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, BreakerStateHalfOpen, dummyApplication.breakerState)
+	assert.Equal(t, dummyRound.id, dummyApplication.probe)
+}
+
+func TestHoldTrippedRound_Probing(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyFailures = rand.IntN(100)
+	var dummyProbe = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:         "some name",
+		session:      dummySession,
+		breakerState: BreakerStateHalfOpen,
+		failures:     dummyFailures,
+		probe:        dummyProbe.id,
+		rounds:       map[uuid.UUID]*round{dummyProbe.id: dummyProbe},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "circuitBreaker", "holdTrippedRound",
+		"Round [%v] skipped by circuit breaker in state [%v] after [%v] consecutive failed round(s)",
+		dummyRound.id, BreakerStateHalfOpen, dummyFailures).Returns().Once()
+
+	// SUT + act
+	var result = holdTrippedRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, dummyProbe.id, dummyApplication.probe)
+}
+
+func TestHoldTrippedRound_ProbeDropped(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:         "some name",
+		session:      dummySession,
+		breakerState: BreakerStateHalfOpen,
+		probe:        uuid.New(),
+		rounds:       map[uuid.UUID]*round{},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "circuitBreaker", "holdTrippedRound",
+		"Round [%v] let through as the probe by circuit breaker in state [%v]",
+		dummyRound.id, BreakerStateHalfOpen).Returns().Once()
+
+	// SUT + act
+	var result = holdTrippedRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, dummyRound.id, dummyApplication.probe)
+}
+
+func TestRecordBreakerResult_Disabled(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		breaker: CircuitBreaker{FailureThreshold: -rand.IntN(10)},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// stub
+	dummyRound.failed.Store(true)
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Zero(t, dummyApplication.failures)
+}
+
+func TestRecordBreakerResult_Cancelled(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		breaker: CircuitBreaker{FailureThreshold: 1},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// stub
+	dummyRound.failed.Store(true)
+	dummyRound.cancelled.Store(true)
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Zero(t, dummyApplication.failures)
+	assert.Equal(t, BreakerStateClosed, dummyApplication.breakerState)
+}
+
+func TestRecordBreakerResult_LockedOut(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	dummyRound.lockedOut.Store(true)
+	var dummyApplication = &application{
+		breaker:      CircuitBreaker{FailureThreshold: 3},
+		breakerState: BreakerStateHalfOpen,
+		failures:     3,
+		probe:        dummyRound.id,
+	}
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 3, dummyApplication.failures)
+	assert.Equal(t, BreakerStateHalfOpen, dummyApplication.breakerState)
+	assert.Equal(t, dummyRound.id, dummyApplication.probe)
+}
+
+func TestRecordBreakerResult_BelowThreshold(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:     "some name",
+		breaker:  CircuitBreaker{FailureThreshold: 3},
+		failures: 1,
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// stub
+	dummyRound.failed.Store(true)
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 2, dummyApplication.failures)
+	assert.Equal(t, BreakerStateClosed, dummyApplication.breakerState)
+}
+
+func TestRecordBreakerResult_ThresholdReached(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:     "some name",
+		breaker:  CircuitBreaker{FailureThreshold: 3},
+		failures: 2,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyTimeNow = time.Now()
+
+	// stub
+	dummyRound.failed.Store(true)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(logBreakerTransition).Expects(dummyApplication, BreakerStateClosed, BreakerStateOpen, 3).Returns().Once()
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 3, dummyApplication.failures)
+	assert.Equal(t, BreakerStateOpen, dummyApplication.breakerState)
+	assert.Equal(t, dummyTimeNow, dummyApplication.openedAt)
+}
+
+func TestRecordBreakerResult_Succeeded(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:     "some name",
+		breaker:  CircuitBreaker{FailureThreshold: 3},
+		failures: 2,
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Zero(t, dummyApplication.failures)
+	assert.Equal(t, BreakerStateClosed, dummyApplication.breakerState)
+}
+
+func TestRecordBreakerResult_ProbeFailed(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:         "some name",
+		breaker:      CircuitBreaker{FailureThreshold: 3},
+		breakerState: BreakerStateHalfOpen,
+		failures:     3,
+		probe:        dummyRound.id,
+	}
+	var dummyTimeNow = time.Now()
+
+	// stub
+	dummyRound.failed.Store(true)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(logBreakerTransition).Expects(dummyApplication, BreakerStateHalfOpen, BreakerStateOpen, 4).Returns().Once()
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, BreakerStateOpen, dummyApplication.breakerState)
+	assert.Equal(t, dummyTimeNow, dummyApplication.openedAt)
+	assert.Equal(t, uuid.Nil, dummyApplication.probe)
+}
+
+func TestRecordBreakerResult_ProbeSucceeded(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyApplication = &application{
+		name:         "some name",
+		breaker:      CircuitBreaker{FailureThreshold: 3},
+		breakerState: BreakerStateHalfOpen,
+		failures:     3,
+		probe:        dummyRound.id,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logBreakerTransition).Expects(dummyApplication, BreakerStateHalfOpen, BreakerStateClosed, 0).Returns().Once()
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Zero(t, dummyApplication.failures)
+	assert.Equal(t, BreakerStateClosed, dummyApplication.breakerState)
+	assert.Equal(t, uuid.Nil, dummyApplication.probe)
+}
+
+func TestRecordBreakerResult_NotProbe(t *testing.T) {
+	// arrange
+	var dummyProbe = uuid.New()
+	var dummyApplication = &application{
+		name:         "some name",
+		breaker:      CircuitBreaker{FailureThreshold: 3},
+		breakerState: BreakerStateHalfOpen,
+		failures:     3,
+		probe:        dummyProbe,
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// stub
+	dummyRound.failed.Store(true)
+
+	// SUT + act
+	recordBreakerResult(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, 4, dummyApplication.failures)
+	assert.Equal(t, BreakerStateHalfOpen, dummyApplication.breakerState)
+	assert.Equal(t, dummyProbe, dummyApplication.probe)
+}
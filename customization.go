@@ -3,6 +3,7 @@ package jobrunner
 import (
 	"crypto/tls"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -11,8 +12,18 @@ import (
 type Customization interface {
 	// BootstrapCustomization holds customization methods related to bootstrapping
 	BootstrapCustomization
+	// SignalCustomization holds customization methods related to OS signal handling
+	SignalCustomization
+	// AdminCustomization holds customization methods related to the admin server
+	AdminCustomization
+	// ScheduleCustomization holds customization methods related to scheduling
+	ScheduleCustomization
 	// HandlerCustomization holds customization methods related to handlers
 	HandlerCustomization
+	// WorkCustomization holds customization methods related to the work-queue mode
+	WorkCustomization
+	// RecordCustomization holds customization methods related to execution records
+	RecordCustomization
 	// LoggingCustomization holds customization methods related to logging
 	LoggingCustomization
 	// WebRequestCustomization holds customization methods related to web requests
@@ -31,6 +42,51 @@ type BootstrapCustomization interface {
 	AppClosing() error
 }
 
+// SignalCustomization holds customization methods related to OS signal handling
+type SignalCustomization interface {
+	// HandleSignals is to customize whether the application traps SIGINT/SIGTERM for a graceful stop and SIGHUP for a reload while running
+	HandleSignals() bool
+
+	// GracefulTimeout is to customize how long a graceful stop waits for running instances to complete before cancelling them
+	GracefulTimeout() time.Duration
+
+	// Reload is to customize the reload logic triggered by SIGHUP when signal handling is enabled, e.g. refreshing configurations, etc.
+	Reload() error
+}
+
+// AdminCustomization holds customization methods related to the admin server
+type AdminCustomization interface {
+	// AdminServer is to customize the embedded admin HTTP server started during bootstrapping, exposing the health, status, errors and history of the application along with endpoints to trigger, pause, resume and stop it; a zero value means no admin server
+	AdminServer() AdminServer
+}
+
+// ScheduleCustomization holds customization methods related to scheduling
+type ScheduleCustomization interface {
+	// PausePolicy is to customize whether the rounds becoming due while paused are skipped or queued till resumed
+	PausePolicy() PausePolicy
+
+	// MaxConcurrentRounds is to customize the cap of rounds running at once under OverlapPolicyAllow; 0 or negative means no cap
+	MaxConcurrentRounds() int
+
+	// OverflowPolicy is to customize whether the rounds becoming due at the cap of concurrent rounds are skipped or queued
+	OverflowPolicy() OverflowPolicy
+
+	// InstanceCount is to customize the number of instances of the given round; 0 or negative falls back to the instances given to NewApplication
+	InstanceCount(round RoundInfo) int
+
+	// Partition is to customize the payloads of the shards of each round, reported to each instance through GetShardPayload
+	Partition(session Session, shardCount int) []any
+
+	// CircuitBreaker is to customize the suspension of the scheduled rounds after consecutive failed rounds; a zero value means no circuit breaker
+	CircuitBreaker() CircuitBreaker
+
+	// LockPolicy is to customize the locking of the scheduled slots across the replicas of the same job runner; a zero value means no locking
+	LockPolicy() LockPolicy
+
+	// CatchUpPolicy is to customize the catch-up of the scheduled slots missed while the application was down; a zero value means no catch-up
+	CatchUpPolicy() CatchUpPolicy
+}
+
 // HandlerCustomization holds customization methods related to handlers
 type HandlerCustomization interface {
 	// PreAction is to customize the pre-action used before each job action takes place, e.g. authorization, etc.
@@ -44,6 +100,42 @@ type HandlerCustomization interface {
 
 	// RecoverPanic is to customize the recovery of panic into a valid response and error in case it happens (for recoverable panic only)
 	RecoverPanic(session Session, recoverResult any) error
+
+	// ActionTimeout is to customize how long an instance or a work item could take including its retries; 0 or negative means no timeout
+	ActionTimeout() time.Duration
+
+	// RetryPolicy is to customize the retries of the failed attempts of an instance or a work item within the same round
+	RetryPolicy() RetryPolicy
+
+	// Stages is to customize the named stages executed by each instance in place of PreAction, ActionFunc and PostAction
+	Stages() []Stage
+}
+
+// WorkCustomization holds customization methods related to the work-queue mode
+type WorkCustomization interface {
+	// WorkQueue is to customize whether the application runs in the work-queue mode, where ProduceWork is executed once per round and the instances become a worker pool consuming the produced items through ConsumeWork instead of executing ActionFunc
+	WorkQueue() bool
+
+	// ProduceWork is to customize the producer of the work items for each round in the work-queue mode, e.g. fetching a batch of records; use slices.Values for a slice of items, or any iterator for streaming them
+	ProduceWork(session Session) (iter.Seq[any], error)
+
+	// ConsumeWork is to customize the processing of a single work item in the work-queue mode, executed within a dedicated child session of the worker instance
+	ConsumeWork(session Session, item any) error
+}
+
+// RecordCustomization holds customization methods related to execution records
+type RecordCustomization interface {
+	// ErrorRetention is to customize how many of the latest run errors are retained in memory for querying, e.g. through LastErrors, ErrorsSince, etc.
+	ErrorRetention() int
+
+	// HistoryRetention is to customize how many of the latest completed rounds are retained in memory for querying through History
+	HistoryRetention() int
+
+	// HistoryStore is to customize the store persisting the records of rounds and instances, e.g. NewFileHistoryStore; if not set or nil, the records are kept in memory only
+	HistoryStore() HistoryStore
+
+	// SpanExporter is to customize the exporter of the tracing spans of rounds, instances and webcalls, e.g. NewMemorySpanExporter, NewFileSpanExporter; if not set or nil, no spans are traced and no traceparent header is sent
+	SpanExporter() SpanExporter
 }
 
 // LoggingCustomization holds customization methods related to logging
@@ -92,6 +184,66 @@ func (customization *DefaultCustomization) AppClosing() error {
 	return nil
 }
 
+// HandleSignals is to customize whether the application traps SIGINT/SIGTERM for a graceful stop and SIGHUP for a reload while running
+func (customization *DefaultCustomization) HandleSignals() bool {
+	return false
+}
+
+// GracefulTimeout is to customize how long a graceful stop waits for running instances to complete before cancelling them
+func (customization *DefaultCustomization) GracefulTimeout() time.Duration {
+	return 30 * time.Second
+}
+
+// Reload is to customize the reload logic triggered by SIGHUP when signal handling is enabled, e.g. refreshing configurations, etc.
+func (customization *DefaultCustomization) Reload() error {
+	return nil
+}
+
+// AdminServer is to customize the embedded admin HTTP server started during bootstrapping, exposing the health, status, errors and history of the application along with endpoints to trigger, pause, resume and stop it; a zero value means no admin server
+func (customization *DefaultCustomization) AdminServer() AdminServer {
+	return AdminServer{}
+}
+
+// PausePolicy is to customize whether the rounds becoming due while paused are skipped or queued till resumed
+func (customization *DefaultCustomization) PausePolicy() PausePolicy {
+	return PausePolicySkip
+}
+
+// MaxConcurrentRounds is to customize the cap of rounds running at once under OverlapPolicyAllow; 0 or negative means no cap
+func (customization *DefaultCustomization) MaxConcurrentRounds() int {
+	return 0
+}
+
+// OverflowPolicy is to customize whether the rounds becoming due at the cap of concurrent rounds are skipped or queued
+func (customization *DefaultCustomization) OverflowPolicy() OverflowPolicy {
+	return OverflowPolicySkip
+}
+
+// InstanceCount is to customize the number of instances of the given round; 0 or negative falls back to the instances given to NewApplication
+func (customization *DefaultCustomization) InstanceCount(round RoundInfo) int {
+	return 0
+}
+
+// Partition is to customize the payloads of the shards of each round, reported to each instance through GetShardPayload
+func (customization *DefaultCustomization) Partition(session Session, shardCount int) []any {
+	return nil
+}
+
+// CircuitBreaker is to customize the suspension of the scheduled rounds after consecutive failed rounds; a zero value means no circuit breaker
+func (customization *DefaultCustomization) CircuitBreaker() CircuitBreaker {
+	return CircuitBreaker{}
+}
+
+// LockPolicy is to customize the locking of the scheduled slots across the replicas of the same job runner; a zero value means no locking
+func (customization *DefaultCustomization) LockPolicy() LockPolicy {
+	return LockPolicy{}
+}
+
+// CatchUpPolicy is to customize the catch-up of the scheduled slots missed while the application was down; a zero value means no catch-up
+func (customization *DefaultCustomization) CatchUpPolicy() CatchUpPolicy {
+	return CatchUpPolicy{}
+}
+
 // PreAction is to customize the pre-action used before each job action takes place, e.g. authorization, etc.
 func (customization *DefaultCustomization) PreAction(session Session) error {
 	return nil
@@ -119,6 +271,56 @@ func (customization *DefaultCustomization) RecoverPanic(session Session, recover
 	return recoverError
 }
 
+// ActionTimeout is to customize how long an instance or a work item could take including its retries; 0 or negative means no timeout
+func (customization *DefaultCustomization) ActionTimeout() time.Duration {
+	return 0
+}
+
+// RetryPolicy is to customize the retries of the failed attempts of an instance or a work item within the same round
+func (customization *DefaultCustomization) RetryPolicy() RetryPolicy {
+	return RetryPolicy{}
+}
+
+// Stages is to customize the named stages executed by each instance in place of PreAction, ActionFunc and PostAction
+func (customization *DefaultCustomization) Stages() []Stage {
+	return nil
+}
+
+// WorkQueue is to customize whether the application runs in the work-queue mode, where ProduceWork is executed once per round and the instances become a worker pool consuming the produced items through ConsumeWork instead of executing ActionFunc
+func (customization *DefaultCustomization) WorkQueue() bool {
+	return false
+}
+
+// ProduceWork is to customize the producer of the work items for each round in the work-queue mode, e.g. fetching a batch of records; use slices.Values for a slice of items, or any iterator for streaming them
+func (customization *DefaultCustomization) ProduceWork(session Session) (iter.Seq[any], error) {
+	return nil, nil
+}
+
+// ConsumeWork is to customize the processing of a single work item in the work-queue mode, executed within a dedicated child session of the worker instance
+func (customization *DefaultCustomization) ConsumeWork(session Session, item any) error {
+	return nil
+}
+
+// ErrorRetention is to customize how many of the latest run errors are retained in memory for querying, e.g. through LastErrors, ErrorsSince, etc.
+func (customization *DefaultCustomization) ErrorRetention() int {
+	return 100
+}
+
+// HistoryRetention is to customize how many of the latest completed rounds are retained in memory for querying through History
+func (customization *DefaultCustomization) HistoryRetention() int {
+	return 100
+}
+
+// HistoryStore is to customize the store persisting the records of rounds and instances, e.g. NewFileHistoryStore; if not set or nil, the records are kept in memory only
+func (customization *DefaultCustomization) HistoryStore() HistoryStore {
+	return nil
+}
+
+// SpanExporter is to customize the exporter of the tracing spans of rounds, instances and webcalls, e.g. NewMemorySpanExporter, NewFileSpanExporter; if not set or nil, no spans are traced and no traceparent header is sent
+func (customization *DefaultCustomization) SpanExporter() SpanExporter {
+	return nil
+}
+
 // Log is to customize the logging backend for the whole application
 func (customization *DefaultCustomization) Log(session Session, logType LogType, logLevel LogLevel, category, subcategory, description string) {
 	fmt.Printf(
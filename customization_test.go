@@ -37,6 +37,198 @@ func TestDefaultCustomization_AppClosing(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDefaultCustomization_HandleSignals(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.HandleSignals()
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestDefaultCustomization_GracefulTimeout(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.GracefulTimeout()
+
+	// assert
+	assert.Equal(t, 30*time.Second, result)
+}
+
+func TestDefaultCustomization_Reload(t *testing.T) {
+	// SUT + act
+	var err = customizationDefault.Reload()
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDefaultCustomization_AdminServer(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.AdminServer()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_PausePolicy(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.PausePolicy()
+
+	// assert
+	assert.Equal(t, PausePolicySkip, result)
+}
+
+func TestDefaultCustomization_MaxConcurrentRounds(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.MaxConcurrentRounds()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_OverflowPolicy(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.OverflowPolicy()
+
+	// assert
+	assert.Equal(t, OverflowPolicySkip, result)
+}
+
+func TestDefaultCustomization_InstanceCount(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.InstanceCount(RoundInfo{ID: uuid.New()})
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_Partition(t *testing.T) {
+	// arrange
+	var dummySession Session
+
+	// SUT + act
+	var result = customizationDefault.Partition(dummySession, rand.IntN(100))
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_CircuitBreaker(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.CircuitBreaker()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_ActionTimeout(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ActionTimeout()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_RetryPolicy(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.RetryPolicy()
+
+	// assert
+	assert.Zero(t, result.MaxAttempts)
+	assert.Nil(t, result.Retryable)
+}
+
+func TestDefaultCustomization_CatchUpPolicy(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.CatchUpPolicy()
+
+	// assert
+	assert.Equal(t, CatchUpModeNone, result.Mode)
+	assert.Zero(t, result.MaxRuns)
+	assert.Nil(t, result.Store)
+}
+
+func TestDefaultCustomization_LockPolicy(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.LockPolicy()
+
+	// assert
+	assert.Nil(t, result.Locker)
+	assert.Zero(t, result.TTL)
+	assert.False(t, result.PerInstance)
+}
+
+func TestDefaultCustomization_Stages(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.Stages()
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestDefaultCustomization_WorkQueue(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.WorkQueue()
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestDefaultCustomization_ProduceWork(t *testing.T) {
+	// arrange
+	var dummySession Session
+
+	// SUT + act
+	var result, err = customizationDefault.ProduceWork(dummySession)
+
+	// assert
+	assert.Nil(t, result)
+	assert.NoError(t, err)
+}
+
+func TestDefaultCustomization_ConsumeWork(t *testing.T) {
+	// arrange
+	var dummySession Session
+	var dummyItem = "some item"
+
+	// SUT + act
+	var err = customizationDefault.ConsumeWork(dummySession, dummyItem)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDefaultCustomization_ErrorRetention(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ErrorRetention()
+
+	// assert
+	assert.Equal(t, 100, result)
+}
+
+func TestDefaultCustomization_HistoryRetention(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.HistoryRetention()
+
+	// assert
+	assert.Equal(t, 100, result)
+}
+
+func TestDefaultCustomization_HistoryStore(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.HistoryStore()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_SpanExporter(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.SpanExporter()
+
+	// assert
+	assert.Nil(t, result)
+}
+
 func TestDefaultCustomization_Log_HappyPath(t *testing.T) {
 	// arrange
 	var dummySession = &session{}
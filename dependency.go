@@ -0,0 +1,192 @@
+package jobrunner
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// reachesJob returns true if the target job is the given job itself or any of its downstream jobs, directly or transitively
+func reachesJob(job *application, target *application) bool {
+	if job == target {
+		return true
+	}
+	for _, downstream := range job.downstreams {
+		if reachesJob(
+			downstream,
+			target,
+		) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerDependency declares the job to run after each successful round of all its upstream jobs, refusing unknown jobs and any dependency which closes a cycle
+func registerDependency(app *application, name string, upstreams []string) error {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	if app.started {
+		return fmt.Errorf(
+			"Runner [%v] is running, unable to declare dependencies of job [%v]",
+			app.name,
+			name,
+		)
+	}
+	var dependent = lookupJob(
+		app,
+		name,
+	)
+	if dependent == nil {
+		return fmt.Errorf(
+			"Runner [%v] has no job [%v] registered",
+			app.name,
+			name,
+		)
+	}
+	for _, upstream := range upstreams {
+		var job = lookupJob(
+			app,
+			upstream,
+		)
+		if job == nil {
+			return fmt.Errorf(
+				"Runner [%v] has no job [%v] registered as upstream of job [%v]",
+				app.name,
+				upstream,
+				name,
+			)
+		}
+		if reachesJob(
+			dependent,
+			job,
+		) {
+			return fmt.Errorf(
+				"Runner [%v] refuses job [%v] depending on job [%v] as it closes a dependency cycle",
+				app.name,
+				name,
+				upstream,
+			)
+		}
+	}
+	for _, upstream := range upstreams {
+		if slices.Contains(
+			dependent.upstreams,
+			upstream,
+		) {
+			continue
+		}
+		var job = lookupJob(
+			app,
+			upstream,
+		)
+		dependent.upstreams = append(
+			dependent.upstreams,
+			upstream,
+		)
+		job.downstreams = append(
+			job.downstreams,
+			dependent,
+		)
+	}
+	if dependent.arrived == nil &&
+		len(dependent.upstreams) > 0 {
+		dependent.arrived = make(chan struct{}, 1)
+		dependent.upstreamRecords = map[string]*RoundRecord{}
+	}
+	return nil
+}
+
+// notifyDownstreams hands the record of a round over to the downstream jobs, each of which runs a round once all its upstream jobs have reported; rounds skipped or coalesced by the overlap policy, or locked by another replica, are not reported
+func notifyDownstreams(app *application, record *RoundRecord) {
+	if record.Status == RoundStatusSkipped ||
+		record.Status == RoundStatusCoalesced ||
+		record.Status == RoundStatusLocked {
+		return
+	}
+	for _, downstream := range app.downstreams {
+		downstream.lock.Lock()
+		downstream.upstreamRecords[app.name] = record
+		if len(downstream.upstreamRecords) < len(downstream.upstreams) {
+			downstream.lock.Unlock()
+			continue
+		}
+		downstream.arrivals = append(
+			downstream.arrivals,
+			downstream.upstreamRecords,
+		)
+		downstream.upstreamRecords = map[string]*RoundRecord{}
+		downstream.lock.Unlock()
+		select {
+		case downstream.arrived <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// awaitUpstreams runs a round of the dependent job each time all its upstream jobs have completed a round, until scheduling is halted
+func awaitUpstreams(app *application) {
+	for {
+		select {
+		case <-app.scheduling.Done():
+			logAppRoot(
+				app.session,
+				"dependency",
+				"awaitUpstreams",
+				"Scheduling halted, terminating execution",
+			)
+			return
+		case <-app.arrived:
+		}
+		app.lock.Lock()
+		var arrivals = app.arrivals
+		app.arrivals = nil
+		app.lock.Unlock()
+		for _, records := range arrivals {
+			runDownstreamRound(
+				app,
+				records,
+			)
+		}
+	}
+}
+
+// runDownstreamRound dispatches a round of the dependent job with the records of the upstream rounds, or skips it if any upstream round failed
+func runDownstreamRound(app *application, records map[string]*RoundRecord) {
+	var incoming = newRound(
+		TriggerSourceUpstream,
+		"",
+		time.Now().UTC(),
+	)
+	incoming.upstream = records
+	for _, upstream := range app.upstreams {
+		var record = records[upstream]
+		if record.Status == RoundStatusCompleted &&
+			!record.Failed {
+			continue
+		}
+		logAppRoot(
+			app.session,
+			"dependency",
+			"runDownstreamRound",
+			"Round [%v] skipped due to round [%v] of upstream job [%v] being [%v] with failure [%v]",
+			incoming.id,
+			record.ID,
+			upstream,
+			record.Status,
+			record.Failed,
+		)
+		recordRound(
+			app,
+			newDroppedRoundRecord(
+				incoming,
+				RoundStatusUpstreamFailed,
+			),
+		)
+		return
+	}
+	dispatchRound(
+		app,
+		incoming,
+	)
+}
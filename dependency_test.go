@@ -0,0 +1,443 @@
+package jobrunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestReachesJob_Self(t *testing.T) {
+	// arrange
+	var dummyJob = &application{name: "some job"}
+
+	// SUT + act
+	var result = reachesJob(
+		dummyJob,
+		dummyJob,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestReachesJob_Transitive(t *testing.T) {
+	// arrange
+	var dummyTarget = &application{name: "job 3"}
+	var dummyJob = &application{
+		name: "job 1",
+		downstreams: []*application{
+			{name: "job 2a"},
+			{name: "job 2b", downstreams: []*application{dummyTarget}},
+		},
+	}
+
+	// SUT + act
+	var result = reachesJob(
+		dummyJob,
+		dummyTarget,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestReachesJob_NotReached(t *testing.T) {
+	// arrange
+	var dummyTarget = &application{name: "job 3"}
+	var dummyJob = &application{
+		name: "job 1",
+		downstreams: []*application{
+			{name: "job 2"},
+		},
+	}
+
+	// SUT + act
+	var result = reachesJob(
+		dummyJob,
+		dummyTarget,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestRegisterDependency_Running(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		started: true,
+	}
+
+	// SUT + act
+	var err = registerDependency(
+		dummyApplication,
+		"some job",
+		[]string{"upstream"},
+	)
+
+	// assert
+	assert.EqualError(t, err, "Runner [some name] is running, unable to declare dependencies of job [some job]")
+}
+
+func TestRegisterDependency_NoJob(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{
+			{name: "upstream"},
+		},
+	}
+
+	// SUT + act
+	var err = registerDependency(
+		dummyApplication,
+		"some job",
+		[]string{"upstream"},
+	)
+
+	// assert
+	assert.EqualError(t, err, "Runner [some name] has no job [some job] registered")
+}
+
+func TestRegisterDependency_NoUpstream(t *testing.T) {
+	// arrange
+	var dummyJob = &application{name: "some job"}
+	var dummyUpstream = &application{name: "upstream 1"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyJob, dummyUpstream},
+	}
+
+	// SUT + act
+	var err = registerDependency(
+		dummyApplication,
+		"some job",
+		[]string{"upstream 1", "upstream 2"},
+	)
+
+	// assert
+	assert.EqualError(t, err, "Runner [some name] has no job [upstream 2] registered as upstream of job [some job]")
+	assert.Empty(t, dummyJob.upstreams)
+	assert.Empty(t, dummyUpstream.downstreams)
+	assert.Nil(t, dummyJob.arrived)
+}
+
+func TestRegisterDependency_Cycle(t *testing.T) {
+	// arrange
+	var dummyUpstream = &application{name: "upstream"}
+	var dummyJob = &application{
+		name:        "some job",
+		downstreams: []*application{{name: "middle", downstreams: []*application{dummyUpstream}}},
+	}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyJob, dummyUpstream},
+	}
+
+	// SUT + act
+	var err = registerDependency(
+		dummyApplication,
+		"some job",
+		[]string{"upstream"},
+	)
+
+	// assert
+	assert.EqualError(t, err, "Runner [some name] refuses job [some job] depending on job [upstream] as it closes a dependency cycle")
+	assert.Empty(t, dummyJob.upstreams)
+	assert.Empty(t, dummyUpstream.downstreams)
+}
+
+func TestRegisterDependency_Success(t *testing.T) {
+	// arrange
+	var dummyUpstream1 = &application{name: "upstream 1"}
+	var dummyUpstream2 = &application{name: "upstream 2"}
+	var dummyJob = &application{name: "some job"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyUpstream1, dummyUpstream2, dummyJob},
+	}
+
+	// SUT + act
+	var err1 = registerDependency(
+		dummyApplication,
+		"some job",
+		[]string{"upstream 1", "upstream 1"},
+	)
+	var arrived = dummyJob.arrived
+	var err2 = registerDependency(
+		dummyApplication,
+		"some job",
+		[]string{"upstream 1", "upstream 2"},
+	)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, []string{"upstream 1", "upstream 2"}, dummyJob.upstreams)
+	assert.Equal(t, []*application{dummyJob}, dummyUpstream1.downstreams)
+	assert.Equal(t, []*application{dummyJob}, dummyUpstream2.downstreams)
+	assert.NotNil(t, arrived)
+	assert.Equal(t, arrived, dummyJob.arrived)
+	assert.Equal(t, 1, cap(dummyJob.arrived))
+	assert.Empty(t, dummyJob.upstreamRecords)
+}
+
+func TestRegisterDependency_NoneDeclared(t *testing.T) {
+	// arrange
+	var dummyJob = &application{name: "some job"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyJob},
+	}
+
+	// SUT + act
+	var err = registerDependency(
+		dummyApplication,
+		"some job",
+		nil,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, dummyJob.arrived)
+}
+
+func TestNotifyDownstreams_Dropped(t *testing.T) {
+	// arrange
+	var dummyDownstream = &application{
+		name:            "some job",
+		upstreams:       []string{"some name"},
+		upstreamRecords: map[string]*RoundRecord{},
+		arrived:         make(chan struct{}, 1),
+	}
+	var dummyApplication = &application{
+		name:        "some name",
+		downstreams: []*application{dummyDownstream},
+	}
+
+	for _, status := range []RoundStatus{RoundStatusSkipped, RoundStatusCoalesced, RoundStatusLocked} {
+		// SUT + act
+		notifyDownstreams(
+			dummyApplication,
+			&RoundRecord{ID: uuid.New(), Status: status},
+		)
+
+		// assert
+		assert.Empty(t, dummyDownstream.upstreamRecords)
+		assert.Empty(t, dummyDownstream.arrivals)
+		assert.Empty(t, dummyDownstream.arrived)
+	}
+}
+
+func TestNotifyDownstreams_Waiting(t *testing.T) {
+	// arrange
+	var dummyDownstream = &application{
+		name:            "some job",
+		upstreams:       []string{"other name", "some name"},
+		upstreamRecords: map[string]*RoundRecord{},
+		arrived:         make(chan struct{}, 1),
+	}
+	var dummyApplication = &application{
+		name:        "some name",
+		downstreams: []*application{dummyDownstream},
+	}
+	var dummyRecord1 = &RoundRecord{ID: uuid.New(), Status: RoundStatusCompleted}
+	var dummyRecord2 = &RoundRecord{ID: uuid.New(), Status: RoundStatusCancelled}
+
+	// SUT + act
+	notifyDownstreams(
+		dummyApplication,
+		dummyRecord1,
+	)
+	notifyDownstreams(
+		dummyApplication,
+		dummyRecord2,
+	)
+
+	// assert
+	assert.Equal(t, map[string]*RoundRecord{"some name": dummyRecord2}, dummyDownstream.upstreamRecords)
+	assert.Empty(t, dummyDownstream.arrivals)
+	assert.Empty(t, dummyDownstream.arrived)
+}
+
+func TestNotifyDownstreams_Arrived(t *testing.T) {
+	// arrange
+	var dummyRecord0 = &RoundRecord{ID: uuid.New(), Status: RoundStatusCompleted}
+	var dummyDownstream1 = &application{
+		name:            "job 1",
+		upstreams:       []string{"other name", "some name"},
+		upstreamRecords: map[string]*RoundRecord{"other name": dummyRecord0},
+		arrived:         make(chan struct{}, 1),
+	}
+	var dummyDownstream2 = &application{
+		name:            "job 2",
+		upstreams:       []string{"some name"},
+		upstreamRecords: map[string]*RoundRecord{},
+		arrived:         make(chan struct{}, 1),
+	}
+	var dummyApplication = &application{
+		name:        "some name",
+		downstreams: []*application{dummyDownstream1, dummyDownstream2},
+	}
+	var dummyRecord1 = &RoundRecord{ID: uuid.New(), Status: RoundStatusUpstreamFailed}
+	var dummyRecord2 = &RoundRecord{ID: uuid.New(), Status: RoundStatusCompleted}
+
+	// SUT + act
+	notifyDownstreams(
+		dummyApplication,
+		dummyRecord1,
+	)
+	notifyDownstreams(
+		dummyApplication,
+		dummyRecord2,
+	)
+
+	// assert
+	assert.Equal(t, []map[string]*RoundRecord{
+		{"other name": dummyRecord0, "some name": dummyRecord1},
+	}, dummyDownstream1.arrivals)
+	assert.Equal(t, map[string]*RoundRecord{"some name": dummyRecord2}, dummyDownstream1.upstreamRecords)
+	assert.Len(t, dummyDownstream1.arrived, 1)
+	assert.Equal(t, []map[string]*RoundRecord{
+		{"some name": dummyRecord1},
+		{"some name": dummyRecord2},
+	}, dummyDownstream2.arrivals)
+	assert.Empty(t, dummyDownstream2.upstreamRecords)
+	assert.Len(t, dummyDownstream2.arrived, 1)
+}
+
+func TestAwaitUpstreams_Halted(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		scheduling: dummyScheduling,
+		arrived:    make(chan struct{}, 1),
+	}
+
+	// stub
+	dummyHalt()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "dependency", "awaitUpstreams",
+		"Scheduling halted, terminating execution").Returns().Once()
+
+	// SUT + act
+	awaitUpstreams(
+		dummyApplication,
+	)
+}
+
+func TestAwaitUpstreams_Arrived(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyScheduling, dummyHalt = context.WithCancel(context.Background())
+	var dummyRecords1 = map[string]*RoundRecord{"upstream": {ID: uuid.New()}}
+	var dummyRecords2 = map[string]*RoundRecord{"upstream": {ID: uuid.New()}}
+	var dummyApplication = &application{
+		name:       "some name",
+		session:    dummySession,
+		scheduling: dummyScheduling,
+		arrivals:   []map[string]*RoundRecord{dummyRecords1, dummyRecords2},
+		arrived:    make(chan struct{}, 1),
+	}
+
+	// stub
+	dummyApplication.arrived <- struct{}{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runDownstreamRound).Expects(dummyApplication, dummyRecords1).Returns().Once()
+	m.Mock(runDownstreamRound).Expects(dummyApplication, dummyRecords2).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, dummyHalt)).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "dependency", "awaitUpstreams",
+		"Scheduling halted, terminating execution").Returns().Once()
+
+	// SUT + act
+	awaitUpstreams(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.arrivals)
+}
+
+func TestRunDownstreamRound_UpstreamFailed(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name:      "some name",
+		session:   dummySession,
+		upstreams: []string{"upstream 1", "upstream 2"},
+		history:   newRoundHistory(10),
+	}
+	var dummyRecord1 = &RoundRecord{ID: uuid.New(), Status: RoundStatusCompleted}
+	var dummyRecord2 = &RoundRecord{ID: uuid.New(), Status: RoundStatusCompleted, Failed: true}
+	var dummyRecords = map[string]*RoundRecord{
+		"upstream 1": dummyRecord1,
+		"upstream 2": dummyRecord2,
+	}
+	var dummyRound = &round{id: uuid.New(), trigger: TriggerSourceUpstream}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRound).Expects(TriggerSourceUpstream, "", gomocker.Anything()).Returns(dummyRound).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "dependency", "runDownstreamRound",
+		"Round [%v] skipped due to round [%v] of upstream job [%v] being [%v] with failure [%v]",
+		dummyRound.id, dummyRecord2.ID, "upstream 2", RoundStatusCompleted, true).Returns().Once()
+
+	// SUT + act
+	runDownstreamRound(
+		dummyApplication,
+		dummyRecords,
+	)
+
+	// assert
+	assert.Equal(t, dummyRecords, dummyRound.upstream)
+	var records = dummyApplication.History()
+	assert.Len(t, records, 1)
+	assert.Equal(t, dummyRound.id, records[0].ID)
+	assert.Equal(t, TriggerSourceUpstream, records[0].Trigger)
+	assert.Equal(t, RoundStatusUpstreamFailed, records[0].Status)
+}
+
+func TestRunDownstreamRound_Dispatched(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:      "some name",
+		upstreams: []string{"upstream 1", "upstream 2"},
+	}
+	var dummyRecords = map[string]*RoundRecord{
+		"upstream 1": {ID: uuid.New(), Status: RoundStatusCompleted},
+		"upstream 2": {ID: uuid.New(), Status: RoundStatusCompleted},
+	}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRound).Expects(TriggerSourceUpstream, "", gomocker.Anything()).Returns(dummyRound).Once()
+	m.Mock(dispatchRound).Expects(dummyApplication, dummyRound).Returns().Once()
+
+	// SUT + act
+	runDownstreamRound(
+		dummyApplication,
+		dummyRecords,
+	)
+
+	// assert
+	assert.Equal(t, dummyRecords, dummyRound.upstream)
+}
@@ -1,14 +1,20 @@
 package jobrunner
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrActionTimeout is the error reported for an instance or a work item abandoned after exceeding the customized action timeout
+var ErrActionTimeout = errors.New("action timed out")
+
 func initiateSession(
 	app *application,
+	round *round,
 	index int,
 	reruns int,
 ) *session {
@@ -16,8 +22,13 @@ func initiateSession(
 		id:            uuid.New(),
 		index:         index,
 		reruns:        reruns,
+		attempt:       1,
+		ctx:           round.ctx,
+		round:         round,
 		attachment:    map[string]any{},
+		clients:       app.clients,
 		customization: app.customization,
+		span:          round.span,
 	}
 }
 
@@ -43,39 +54,49 @@ func finalizeSession(
 func processSession(
 	session Session,
 	customization Customization,
-) error {
+) (RunPhase, error) {
 	var preActionError = customization.PreAction(
 		session,
 	)
 	if preActionError != nil {
-		return preActionError
+		return RunPhasePreAction, preActionError
 	}
 	var actionError = customization.ActionFunc(
 		session,
 	)
 	if actionError != nil {
-		return actionError
+		return RunPhaseAction, actionError
 	}
 	var postActionError = customization.PostAction(
 		session,
 	)
 	if postActionError != nil {
-		return postActionError
+		return RunPhasePostAction, postActionError
 	}
-	return nil
+	return RunPhasePostAction, nil
 }
 
-// handleSession wraps the HTTP handler with session related operations
+// handleSession wraps the HTTP handler with session related operations, and returns the record of the instance execution
 func handleSession(
 	app *application,
+	round *round,
 	index int,
 	reruns int,
-) (err error) {
+) (record *InstanceRecord) {
 	var session = initiateSession(
 		app,
+		round,
 		index,
 		reruns,
 	)
+	session.span = startInstanceSpan(
+		app,
+		session,
+	)
+	registerSession(
+		app,
+		session,
+	)
 	logProcessEnter(
 		session,
 		app.name,
@@ -89,12 +110,20 @@ func handleSession(
 		"%v",
 		index,
 	)
+	var phase = RunPhasePreAction
+	var err error
+	var items []*WorkItemRecord
 	defer func(startTime time.Time) {
+		var recoverResult = recover()
+		if recoverResult != nil {
+			phase = RunPhasePanic
+		}
 		err = finalizeSession(
 			session,
 			err,
-			recover(),
+			recoverResult,
 		)
+		var duration = time.Since(startTime)
 		logProcessResponse(
 			session,
 			app.name,
@@ -107,13 +136,149 @@ func handleSession(
 			app.name,
 			"Duration",
 			"%s",
-			time.Since(startTime),
+			duration,
+		)
+		unregisterSession(
+			app,
+			session,
+		)
+		record = newInstanceRecord(
+			round,
+			index,
+			reruns,
+			startTime,
+			duration,
+			phase,
+			err,
+		)
+		record.Items = items
+		observeInstance(
+			app,
+			record,
+		)
+		endInstanceSpan(
+			session,
+			record,
+			err,
 		)
 	}(
 		time.Now().UTC(),
 	)
+	if round.work != nil {
+		items = processWorker(
+			app,
+			session,
+		)
+		return nil
+	}
+	phase, err = superviseSession(
+		app,
+		session,
+	)
+	return nil
+}
+
+// processAttempt executes a single attempt of the session, either for the action or the stages of an instance or for the work item of a worker instance
+func processAttempt(app *application, session *session) (RunPhase, error) {
+	if session.item != nil {
+		return processWorkItem(
+			session,
+			app.customization,
+			session.item.value,
+		)
+	}
+	if len(app.stages) > 0 {
+		return processStages(
+			app,
+			session,
+			app.stages,
+		)
+	}
 	return processSession(
 		session,
 		app.customization,
 	)
 }
+
+// attemptSession executes the session, retrying its failed attempts according to the retry policy
+func attemptSession(app *application, session *session) (RunPhase, error) {
+	var phase, err = processAttempt(
+		app,
+		session,
+	)
+	for err != nil &&
+		retrySession(
+			app,
+			session,
+			phase,
+			err,
+		) {
+		phase, err = processAttempt(
+			app,
+			session,
+		)
+	}
+	return phase, err
+}
+
+// sessionResult is the result of the session executed in background under the supervision of the action timeout
+type sessionResult struct {
+	phase         RunPhase
+	err           error
+	recoverResult any
+}
+
+// superviseSession executes the session under the action timeout if customized; upon expiry, the session context is cancelled and the session is abandoned without waiting for it to return
+func superviseSession(app *application, session *session) (RunPhase, error) {
+	if app.actionTimeout <= 0 {
+		return attemptSession(
+			app,
+			session,
+		)
+	}
+	var ctx, cancel = context.WithTimeoutCause(
+		session.Context(),
+		app.actionTimeout,
+		ErrActionTimeout,
+	)
+	defer cancel()
+	session.ctx = ctx
+	var done = make(chan *sessionResult, 1)
+	go func() {
+		var result = &sessionResult{}
+		defer func() {
+			result.recoverResult = recover()
+			done <- result
+		}()
+		result.phase, result.err = attemptSession(
+			app,
+			session,
+		)
+	}()
+	var result *sessionResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		if !errors.Is(context.Cause(ctx), ErrActionTimeout) {
+			result = <-done
+			break
+		}
+		logMethodLogic(
+			session,
+			LogLevelError,
+			app.name,
+			"superviseSession",
+			"Session timed out after [%v] and is abandoned",
+			app.actionTimeout,
+		)
+		return RunPhaseTimeout, fmt.Errorf(
+			"%w after [%v]",
+			ErrActionTimeout,
+			app.actionTimeout,
+		)
+	}
+	if result.recoverResult != nil {
+		panic(result.recoverResult)
+	}
+	return result.phase, result.err
+}
@@ -1,6 +1,7 @@
 package jobrunner
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand/v2"
@@ -18,12 +19,17 @@ func TestInitiateSession(t *testing.T) {
 		Customization
 	}
 	var dummyCustomization = &customization{}
+	var dummyClients = &httpClients{}
 	var dummyApplication = &application{
+		clients:       dummyClients,
 		customization: dummyCustomization,
 	}
 	var dummyIndex = rand.IntN(65536)
 	var dummyReruns = rand.IntN(65536)
 	var dummySessionID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	var dummyContext = context.TODO()
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyRound = &round{ctx: dummyContext, span: dummySpan}
 
 	// mock
 	var m = gomocker.NewMocker(t)
@@ -34,6 +40,7 @@ func TestInitiateSession(t *testing.T) {
 	// SUT + act
 	var session = initiateSession(
 		dummyApplication,
+		dummyRound,
 		dummyIndex,
 		dummyReruns,
 	)
@@ -43,8 +50,13 @@ func TestInitiateSession(t *testing.T) {
 	assert.Equal(t, dummySessionID, session.id)
 	assert.Equal(t, dummyIndex, session.index)
 	assert.Equal(t, dummyReruns, session.reruns)
+	assert.Equal(t, 1, session.attempt)
+	assert.Equal(t, dummyContext, session.ctx)
+	assert.Equal(t, dummyRound, session.round)
 	assert.Empty(t, session.attachment)
+	assert.Equal(t, dummyClients, session.clients)
 	assert.Equal(t, dummyCustomization, session.customization)
+	assert.Equal(t, dummySpan, session.span)
 }
 
 func TestFinalizeSession_NoErrorResult(t *testing.T) {
@@ -129,12 +141,13 @@ func TestProcessSession_PreActionError(t *testing.T) {
 	m.Mock((*customization).PreAction).Expects(dummyCustomization, dummySession).Returns(dummyError).Once()
 
 	// SUT + act
-	var err = processSession(
+	var phase, err = processSession(
 		dummySession,
 		dummyCustomization,
 	)
 
 	// assert
+	assert.Equal(t, RunPhasePreAction, phase)
 	assert.Equal(t, dummyError, err)
 
 }
@@ -158,12 +171,13 @@ func TestProcessSession_ActionFuncError(t *testing.T) {
 	m.Mock((*customization).ActionFunc).Expects(dummyCustomization, dummySession).Returns(dummyError).Once()
 
 	// SUT + act
-	var err = processSession(
+	var phase, err = processSession(
 		dummySession,
 		dummyCustomization,
 	)
 
 	// assert
+	assert.Equal(t, RunPhaseAction, phase)
 	assert.Equal(t, dummyError, err)
 
 }
@@ -188,12 +202,13 @@ func TestProcessSession_PostActionError(t *testing.T) {
 	m.Mock((*customization).PostAction).Expects(dummyCustomization, dummySession).Returns(dummyError).Once()
 
 	// SUT + act
-	var err = processSession(
+	var phase, err = processSession(
 		dummySession,
 		dummyCustomization,
 	)
 
 	// assert
+	assert.Equal(t, RunPhasePostAction, phase)
 	assert.Equal(t, dummyError, err)
 
 }
@@ -217,12 +232,13 @@ func TestProcessSession_Success(t *testing.T) {
 	m.Mock((*customization).PostAction).Expects(dummyCustomization, dummySession).Returns(nil).Once()
 
 	// SUT + act
-	var err = processSession(
+	var phase, err = processSession(
 		dummySession,
 		dummyCustomization,
 	)
 
 	// assert
+	assert.Equal(t, RunPhasePostAction, phase)
 	assert.NoError(t, err)
 
 }
@@ -238,35 +254,493 @@ func TestHandleSession_HappyPath(t *testing.T) {
 		name:          dummyName,
 		customization: dummyCustomization,
 	}
+	var dummyRound = &round{}
 	var dummyIndex = rand.Int()
 	var dummyReruns = rand.Int()
 	var dummySession = &session{id: uuid.New()}
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyDuration = time.Duration(rand.IntN(100))
+	var dummyRecord = &InstanceRecord{Outcome: InstanceOutcomeSuccess}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, dummyIndex, dummyReruns).Returns(dummySession).Once()
+	m.Mock(startInstanceSpan).Expects(dummyApplication, dummySession).Returns(dummySpan).Once()
+	m.Mock(registerSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "", "").Returns().Once()
+	m.Mock(logProcessRequest).Expects(dummySession, dummyName, "InstanceIndex", "%v", dummyIndex).Returns().Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(superviseSession).Expects(dummyApplication, dummySession).Returns(RunPhasePostAction, nil).Once()
+	m.Mock(finalizeSession).Expects(dummySession, nil, nil).Returns(nil).Once()
+	m.Mock(time.Since).Expects(dummyTimeNow).Returns(dummyDuration).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", nil).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Duration", "%s", dummyDuration).Returns().Once()
+	m.Mock(unregisterSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(newInstanceRecord).Expects(dummyRound, dummyIndex, dummyReruns, dummyTimeNow, dummyDuration, RunPhasePostAction, nil).Returns(dummyRecord).Once()
+	m.Mock(endInstanceSpan).Expects(dummySession, dummyRecord, nil).Returns().Once()
+
+	// SUT + act
+	var result = handleSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, dummySession.span)
+	assert.Equal(t, dummyRecord, result)
+}
+
+func TestHandleSession_Error(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{}
+	var dummyIndex = rand.Int()
+	var dummyReruns = rand.Int()
+	var dummySession = &session{id: uuid.New()}
+	var dummySpan = &Span{SpanID: "some span"}
 	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
 	var dummyDuration = time.Duration(rand.IntN(100))
 	var dummyProcessError = errors.New("some process error")
 	var dummyFinalError = errors.New("some final error")
+	var dummyRecord = &InstanceRecord{Err: &RunError{Err: dummyFinalError}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, dummyIndex, dummyReruns).Returns(dummySession).Once()
+	m.Mock(startInstanceSpan).Expects(dummyApplication, dummySession).Returns(dummySpan).Once()
+	m.Mock(registerSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "", "").Returns().Once()
+	m.Mock(logProcessRequest).Expects(dummySession, dummyName, "InstanceIndex", "%v", dummyIndex).Returns().Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(superviseSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, dummyProcessError).Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyProcessError, nil).Returns(dummyFinalError).Once()
+	m.Mock(time.Since).Expects(dummyTimeNow).Returns(dummyDuration).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Duration", "%s", dummyDuration).Returns().Once()
+	m.Mock(unregisterSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(newInstanceRecord).Expects(dummyRound, dummyIndex, dummyReruns, dummyTimeNow, dummyDuration, RunPhaseAction, dummyFinalError).Returns(dummyRecord).Once()
+	m.Mock(endInstanceSpan).Expects(dummySession, dummyRecord, dummyFinalError).Returns().Once()
+
+	// SUT + act
+	var result = handleSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, dummySession.span)
+	assert.Equal(t, dummyRecord, result)
+}
+
+func TestHandleSession_Panic(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{}
+	var dummyIndex = rand.Int()
+	var dummyReruns = rand.Int()
+	var dummySession = &session{id: uuid.New()}
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyDuration = time.Duration(rand.IntN(100))
+	var dummyPanic = "some panic"
+	var dummyFinalError = errors.New("some final error")
+	var dummyRecord = &InstanceRecord{Err: &RunError{Err: dummyFinalError}}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(initiateSession).Expects(dummyApplication, dummyIndex, dummyReruns).Returns(dummySession).Once()
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, dummyIndex, dummyReruns).Returns(dummySession).Once()
+	m.Mock(startInstanceSpan).Expects(dummyApplication, dummySession).Returns(dummySpan).Once()
+	m.Mock(registerSession).Expects(dummyApplication, dummySession).Returns().Once()
 	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "", "").Returns().Once()
 	m.Mock(logProcessRequest).Expects(dummySession, dummyName, "InstanceIndex", "%v", dummyIndex).Returns().Once()
 	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
-	m.Mock(processSession).Expects(dummySession, dummyCustomization).Returns(dummyProcessError).Once()
-	m.Mock(finalizeSession).Expects(dummySession, dummyProcessError, recover()).Returns(dummyFinalError).Once()
+	m.Mock(superviseSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { panic(dummyPanic) })).Once()
+	m.Mock(finalizeSession).Expects(dummySession, nil, dummyPanic).Returns(dummyFinalError).Once()
 	m.Mock(time.Since).Expects(dummyTimeNow).Returns(dummyDuration).Once()
 	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
 	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Duration", "%s", dummyDuration).Returns().Once()
+	m.Mock(unregisterSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(newInstanceRecord).Expects(dummyRound, dummyIndex, dummyReruns, dummyTimeNow, dummyDuration, RunPhasePanic, dummyFinalError).Returns(dummyRecord).Once()
+	m.Mock(endInstanceSpan).Expects(dummySession, dummyRecord, dummyFinalError).Returns().Once()
+
+	// SUT + act
+	var result = handleSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, dummySession.span)
+	assert.Equal(t, dummyRecord, result)
+}
+
+func TestHandleSession_WorkQueue(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{work: make(chan *workItem)}
+	var dummyIndex = rand.Int()
+	var dummyReruns = rand.Int()
+	var dummySession = &session{id: uuid.New()}
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyDuration = time.Duration(rand.IntN(100))
+	var dummyItems = []*WorkItemRecord{
+		{Sequence: 0},
+		{Sequence: 1},
+	}
+	var dummyRecord = &InstanceRecord{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, dummyIndex, dummyReruns).Returns(dummySession).Once()
+	m.Mock(startInstanceSpan).Expects(dummyApplication, dummySession).Returns(dummySpan).Once()
+	m.Mock(registerSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "", "").Returns().Once()
+	m.Mock(logProcessRequest).Expects(dummySession, dummyName, "InstanceIndex", "%v", dummyIndex).Returns().Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(processWorker).Expects(dummyApplication, dummySession).Returns(dummyItems).Once()
+	m.Mock(finalizeSession).Expects(dummySession, nil, nil).Returns(nil).Once()
+	m.Mock(time.Since).Expects(dummyTimeNow).Returns(dummyDuration).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", nil).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Duration", "%s", dummyDuration).Returns().Once()
+	m.Mock(unregisterSession).Expects(dummyApplication, dummySession).Returns().Once()
+	m.Mock(newInstanceRecord).Expects(dummyRound, dummyIndex, dummyReruns, dummyTimeNow, dummyDuration, RunPhasePreAction, nil).Returns(dummyRecord).Once()
+	m.Mock(endInstanceSpan).Expects(dummySession, dummyRecord, nil).Returns().Once()
 
 	// SUT + act
-	var err = handleSession(
+	var result = handleSession(
 		dummyApplication,
+		dummyRound,
 		dummyIndex,
 		dummyReruns,
 	)
 
 	// assert
-	assert.Equal(t, dummyFinalError, err)
+	assert.Equal(t, dummySpan, dummySession.span)
+	assert.Equal(t, dummyRecord, result)
+	assert.Equal(t, dummyItems, result.Items)
+}
+
+func TestProcessAttempt_Action(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		customization: dummyCustomization,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(processSession).Expects(dummySession, dummyCustomization).Returns(RunPhaseAction, dummyError).Once()
+
+	// SUT + act
+	var phase, err = processAttempt(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseAction, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestProcessAttempt_Stages(t *testing.T) {
+	// arrange
+	var dummyStages = []Stage{{Name: "some stage"}}
+	var dummyApplication = &application{
+		stages: dummyStages,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(processStages).Expects(dummyApplication, dummySession, dummyStages).Returns(RunPhaseStage, dummyError).Once()
+
+	// SUT + act
+	var phase, err = processAttempt(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseStage, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestProcessAttempt_WorkItem(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		customization: dummyCustomization,
+	}
+	var dummyItem = &workItem{sequence: rand.IntN(100), value: "some item"}
+	var dummySession = &session{id: uuid.New(), item: dummyItem}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(processWorkItem).Expects(dummySession, dummyCustomization, dummyItem.value).Returns(RunPhaseConsumeWork, dummyError).Once()
+
+	// SUT + act
+	var phase, err = processAttempt(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseConsumeWork, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestAttemptSession_Success(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummySession = &session{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(processAttempt).Expects(dummyApplication, dummySession).Returns(RunPhasePostAction, nil).Once()
+
+	// SUT + act
+	var phase, err = attemptSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhasePostAction, phase)
+	assert.NoError(t, err)
+}
+
+func TestAttemptSession_Retried(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyError1 = errors.New("some error 1")
+	var dummyError2 = errors.New("some error 2")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(processAttempt).Expects(dummyApplication, dummySession).Returns(RunPhasePreAction, dummyError1).Once()
+	m.Mock(retrySession).Expects(dummyApplication, dummySession, RunPhasePreAction, dummyError1).Returns(true).Once()
+	m.Mock(processAttempt).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, dummyError2).Once()
+	m.Mock(retrySession).Expects(dummyApplication, dummySession, RunPhaseAction, dummyError2).Returns(false).Once()
+
+	// SUT + act
+	var phase, err = attemptSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseAction, phase)
+	assert.Equal(t, dummyError2, err)
+}
+
+func TestSuperviseSession_NoTimeout(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(attemptSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, dummyError).Once()
+
+	// SUT + act
+	var phase, err = superviseSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseAction, phase)
+	assert.Equal(t, dummyError, err)
+	assert.Nil(t, dummySession.ctx)
+}
+
+func TestSuperviseSession_Completed(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:          "some name",
+		actionTimeout: time.Hour,
+	}
+	var dummySession = &session{id: uuid.New(), ctx: context.Background()}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(attemptSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, dummyError).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { assert.NoError(t, dummySession.Context().Err()) })).Once()
+
+	// SUT + act
+	var phase, err = superviseSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseAction, phase)
+	assert.Equal(t, dummyError, err)
+	assert.Error(t, dummySession.ctx.Err())
+}
+
+func TestSuperviseSession_Panic(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:          "some name",
+		actionTimeout: time.Hour,
+	}
+	var dummySession = &session{id: uuid.New(), ctx: context.Background()}
+	var dummyPanic = "some panic"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(attemptSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { panic(dummyPanic) })).Once()
+
+	// SUT + act
+	assert.PanicsWithValue(t, dummyPanic, func() {
+		superviseSession(
+			dummyApplication,
+			dummySession,
+		)
+	})
+}
+
+func TestSuperviseSession_ParentCancelled(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:          "some name",
+		actionTimeout: time.Hour,
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummySession = &session{id: uuid.New(), ctx: dummyContext}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(attemptSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, dummyError).SideEffects(
+		gomocker.GeneralSideEffect(0, func() {
+			dummyCancel()
+			time.Sleep(10 * time.Millisecond)
+		})).Once()
+
+	// SUT + act
+	var phase, err = superviseSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseAction, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestSuperviseSession_TimedOut(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyTimeout = 10 * time.Millisecond
+	var dummyApplication = &application{
+		name:          dummyName,
+		actionTimeout: dummyTimeout,
+	}
+	var dummySession = &session{id: uuid.New(), ctx: context.Background()}
+	var release = make(chan bool)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(attemptSession).Expects(dummyApplication, dummySession).Returns(RunPhaseAction, nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { <-release })).Once()
+	m.Mock(logMethodLogic).Expects(dummySession, LogLevelError, dummyName, "superviseSession",
+		"Session timed out after [%v] and is abandoned", dummyTimeout).Returns().Once()
+
+	// SUT + act
+	var phase, err = superviseSession(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseTimeout, phase)
+	assert.ErrorIs(t, err, ErrActionTimeout)
+	assert.Equal(t, "action timed out after [10ms]", err.Error())
+	assert.ErrorIs(t, context.Cause(dummySession.ctx), ErrActionTimeout)
+
+	// cleanup
+	close(release)
 }
@@ -0,0 +1,265 @@
+package jobrunner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InstanceRecord is the record of the execution of a single instance within a round
+type InstanceRecord struct {
+	// Index is the index of the instance
+	Index int `json:"index"`
+	// Reruns is the rerun count of the instance
+	Reruns int `json:"reruns"`
+	// StartTime is the time when the instance started
+	StartTime time.Time `json:"startTime"`
+	// EndTime is the time when the instance ended
+	EndTime time.Time `json:"endTime"`
+	// Duration is how long the instance took to complete
+	Duration time.Duration `json:"duration"`
+	// Outcome is the outcome of the instance
+	Outcome InstanceOutcome `json:"outcome"`
+	// Err is the run error of the instance, or nil if succeeded
+	Err *RunError `json:"error,omitempty"`
+	// Items are the records of the work items processed by the instance in the work-queue mode, in processing order
+	Items []*WorkItemRecord `json:"items,omitempty"`
+}
+
+// WorkItemRecord is the record of the processing of a single work item by a worker instance in the work-queue mode
+type WorkItemRecord struct {
+	// Sequence is the sequence of the work item as produced within the round
+	Sequence int `json:"sequence"`
+	// StartTime is the time when the processing of the work item started
+	StartTime time.Time `json:"startTime"`
+	// EndTime is the time when the processing of the work item ended
+	EndTime time.Time `json:"endTime"`
+	// Duration is how long the processing of the work item took
+	Duration time.Duration `json:"duration"`
+	// Outcome is the outcome of the processing of the work item
+	Outcome InstanceOutcome `json:"outcome"`
+	// Err is the run error of the processing of the work item, or nil if succeeded
+	Err *RunError `json:"error,omitempty"`
+}
+
+// RoundRecord is the record of the execution of a single round of instances
+type RoundRecord struct {
+	// ID is the ID of the round
+	ID uuid.UUID `json:"id"`
+	// Trigger is how the round was initiated
+	Trigger TriggerSource `json:"trigger"`
+	// Reason is the reason given when the round was triggered manually
+	Reason string `json:"reason,omitempty"`
+	// ScheduledTime is the time when the round was due
+	ScheduledTime time.Time `json:"scheduledTime"`
+	// Status is the status of the round, i.e. whether it was executed to completion, cancelled, or never executed due to the overlap policy
+	Status RoundStatus `json:"status"`
+	// StartTime is the time when the round actually started, or zero if never executed
+	StartTime time.Time `json:"startTime"`
+	// EndTime is the time when the last instance of the round ended, or zero if never executed
+	EndTime time.Time `json:"endTime"`
+	// Failed is whether any run error occurred during the round, e.g. a failed instance or work producer
+	Failed bool `json:"failed"`
+	// Instances are the records of all instances executed in the round, ordered by index
+	Instances []*InstanceRecord `json:"instances"`
+}
+
+func newInstanceRecord(
+	round *round,
+	index int,
+	reruns int,
+	startTime time.Time,
+	duration time.Duration,
+	phase RunPhase,
+	err error,
+) *InstanceRecord {
+	var record = &InstanceRecord{
+		Index:     index,
+		Reruns:    reruns,
+		StartTime: startTime,
+		EndTime:   startTime.Add(duration),
+		Duration:  duration,
+		Outcome:   InstanceOutcomeSuccess,
+	}
+	if err == nil {
+		return record
+	}
+	record.Outcome = InstanceOutcomeFailure
+	if phase == RunPhasePanic {
+		record.Outcome = InstanceOutcomePanic
+	} else if phase == RunPhaseTimeout {
+		record.Outcome = InstanceOutcomeTimeout
+	}
+	record.Err = newRunError(
+		round,
+		index,
+		reruns,
+		phase,
+		err,
+	)
+	return record
+}
+
+func newWorkItemRecord(
+	round *round,
+	index int,
+	reruns int,
+	sequence int,
+	startTime time.Time,
+	duration time.Duration,
+	phase RunPhase,
+	err error,
+) *WorkItemRecord {
+	var instance = newInstanceRecord(
+		round,
+		index,
+		reruns,
+		startTime,
+		duration,
+		phase,
+		err,
+	)
+	if instance.Err != nil {
+		instance.Err.Item = sequence
+	}
+	return &WorkItemRecord{
+		Sequence:  sequence,
+		StartTime: instance.StartTime,
+		EndTime:   instance.EndTime,
+		Duration:  instance.Duration,
+		Outcome:   instance.Outcome,
+		Err:       instance.Err,
+	}
+}
+
+func newRoundRecord(
+	round *round,
+	startTime time.Time,
+	instances []*InstanceRecord,
+) *RoundRecord {
+	var status = RoundStatusCompleted
+	if round.cancelled.Load() {
+		status = RoundStatusCancelled
+	}
+	return &RoundRecord{
+		ID:            round.id,
+		Trigger:       round.trigger,
+		Reason:        round.reason,
+		ScheduledTime: round.scheduled,
+		Status:        status,
+		StartTime:     startTime,
+		EndTime:       time.Now().UTC(),
+		Failed:        round.failed.Load(),
+		Instances:     instances,
+	}
+}
+
+// newDroppedRoundRecord creates the record of a round which is never executed, e.g. skipped or coalesced due to the overlap policy
+func newDroppedRoundRecord(
+	round *round,
+	status RoundStatus,
+) *RoundRecord {
+	return &RoundRecord{
+		ID:            round.id,
+		Trigger:       round.trigger,
+		Reason:        round.reason,
+		ScheduledTime: round.scheduled,
+		Status:        status,
+		Instances:     []*InstanceRecord{},
+	}
+}
+
+// roundHistory keeps the records of the latest completed rounds, where the oldest records are dropped once exceeding the retention
+type roundHistory struct {
+	lock      sync.Mutex
+	retention int
+	records   []*RoundRecord
+}
+
+func newRoundHistory(retention int) *roundHistory {
+	if retention < 1 {
+		retention = 1
+	}
+	return &roundHistory{
+		retention: retention,
+		records:   []*RoundRecord{},
+	}
+}
+
+func (history *roundHistory) add(record *RoundRecord) {
+	history.lock.Lock()
+	defer history.lock.Unlock()
+	history.records = append(
+		history.records,
+		record,
+	)
+	if len(history.records) > history.retention {
+		history.records = history.records[len(history.records)-history.retention:]
+	}
+}
+
+// list returns the retained records, from the oldest to the latest
+func (history *roundHistory) list() []*RoundRecord {
+	history.lock.Lock()
+	defer history.lock.Unlock()
+	return append(
+		[]*RoundRecord{},
+		history.records...,
+	)
+}
+
+func recordInstance(app *application, round *round, record *InstanceRecord) {
+	if isInterfaceValueNil(app.store) {
+		return
+	}
+	var saveError = app.store.SaveInstance(
+		round.id,
+		record,
+	)
+	if saveError != nil {
+		logAppRoot(
+			app.session,
+			"history",
+			"recordInstance",
+			"Failed to save instance [%v] of round [%v] to history store. Error: %+v",
+			record.Index,
+			round.id,
+			saveError,
+		)
+	}
+}
+
+func recordRound(app *application, record *RoundRecord) {
+	app.history.add(
+		record,
+	)
+	observeRound(
+		app,
+		record,
+	)
+	notifyDownstreams(
+		app,
+		record,
+	)
+	saveSlot(
+		app,
+		record,
+	)
+	if isInterfaceValueNil(app.store) {
+		return
+	}
+	var saveError = app.store.SaveRound(
+		record,
+	)
+	if saveError != nil {
+		logAppRoot(
+			app.session,
+			"history",
+			"recordRound",
+			"Failed to save round [%v] to history store. Error: %+v",
+			record.ID,
+			saveError,
+		)
+	}
+}
@@ -0,0 +1,266 @@
+package jobrunner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HistoryStore is the interface for persisting the records of rounds and instances, e.g. for an audit trail surviving restarts
+type HistoryStore interface {
+	// SaveInstance persists the record of an instance as soon as it completes
+	SaveInstance(roundID uuid.UUID, record *InstanceRecord) error
+	// SaveRound persists the record of a round, including all its instance records, as soon as all instances complete
+	SaveRound(record *RoundRecord) error
+}
+
+// HistoryEntry is a single entry persisted by the history store, holding either a round record or an instance record
+type HistoryEntry struct {
+	// Timestamp is the time when the entry was persisted
+	Timestamp time.Time `json:"timestamp"`
+	// RoundID is the ID of the round the entry belongs to
+	RoundID uuid.UUID `json:"roundId"`
+	// Round is the round record, or nil if the entry holds an instance record
+	Round *RoundRecord `json:"round,omitempty"`
+	// Instance is the instance record, or nil if the entry holds a round record
+	Instance *InstanceRecord `json:"instance,omitempty"`
+}
+
+type fileHistoryStore struct {
+	lock       sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+}
+
+// NewFileHistoryStore creates a history store appending entries as JSON lines to the file at the given path
+//
+//	maxSize is the size in bytes the file could grow up to before being rotated; 0 or negative disables the rotation
+//	maxBackups is how many rotated files are kept, named with suffixes .1 (the latest) to .N (the oldest); 0 or negative discards the rotated content
+func NewFileHistoryStore(path string, maxSize int64, maxBackups int) HistoryStore {
+	return &fileHistoryStore{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+}
+
+// SaveInstance persists the record of an instance as soon as it completes
+func (store *fileHistoryStore) SaveInstance(roundID uuid.UUID, record *InstanceRecord) error {
+	return writeHistoryEntry(
+		store,
+		&HistoryEntry{
+			Timestamp: time.Now().UTC(),
+			RoundID:   roundID,
+			Instance:  record,
+		},
+	)
+}
+
+// SaveRound persists the record of a round, including all its instance records, as soon as all instances complete
+func (store *fileHistoryStore) SaveRound(record *RoundRecord) error {
+	return writeHistoryEntry(
+		store,
+		&HistoryEntry{
+			Timestamp: time.Now().UTC(),
+			RoundID:   record.ID,
+			Round:     record,
+		},
+	)
+}
+
+func getHistoryBackupPath(path string, index int) string {
+	return fmt.Sprintf(
+		"%v.%v",
+		path,
+		index,
+	)
+}
+
+func renameHistoryFile(oldPath string, newPath string) error {
+	var renameError = os.Rename(
+		oldPath,
+		newPath,
+	)
+	if renameError != nil &&
+		!os.IsNotExist(renameError) {
+		return renameError
+	}
+	return nil
+}
+
+// rotateHistoryFile rotates the history file if appending the given number of bytes would make it exceed the max size
+func rotateHistoryFile(store *fileHistoryStore, incoming int64) error {
+	if store.maxSize <= 0 {
+		return nil
+	}
+	var fileInfo, statError = os.Stat(
+		store.path,
+	)
+	if statError != nil {
+		if os.IsNotExist(statError) {
+			return nil
+		}
+		return statError
+	}
+	if fileInfo.Size() == 0 ||
+		fileInfo.Size()+incoming <= store.maxSize {
+		return nil
+	}
+	if store.maxBackups <= 0 {
+		return os.Remove(
+			store.path,
+		)
+	}
+	var removeError = os.Remove(
+		getHistoryBackupPath(store.path, store.maxBackups),
+	)
+	if removeError != nil &&
+		!os.IsNotExist(removeError) {
+		return removeError
+	}
+	for index := store.maxBackups - 1; index > 0; index-- {
+		var renameError = renameHistoryFile(
+			getHistoryBackupPath(store.path, index),
+			getHistoryBackupPath(store.path, index+1),
+		)
+		if renameError != nil {
+			return renameError
+		}
+	}
+	return renameHistoryFile(
+		store.path,
+		getHistoryBackupPath(store.path, 1),
+	)
+}
+
+func writeHistoryEntry(store *fileHistoryStore, entry *HistoryEntry) error {
+	var content, marshalError = json.Marshal(
+		entry,
+	)
+	if marshalError != nil {
+		return marshalError
+	}
+	content = append(
+		content,
+		'\n',
+	)
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	var rotateError = rotateHistoryFile(
+		store,
+		int64(len(content)),
+	)
+	if rotateError != nil {
+		return rotateError
+	}
+	var file, openError = os.OpenFile(
+		store.path,
+		os.O_CREATE|os.O_APPEND|os.O_WRONLY,
+		0644,
+	)
+	if openError != nil {
+		return openError
+	}
+	defer file.Close()
+	var _, writeError = file.Write(
+		content,
+	)
+	return writeError
+}
+
+func readHistoryFile(path string, filter func(entry *HistoryEntry) bool) ([]*HistoryEntry, error) {
+	var entries = []*HistoryEntry{}
+	var file, openError = os.Open(
+		path,
+	)
+	if openError != nil {
+		if os.IsNotExist(openError) {
+			return entries, nil
+		}
+		return nil, openError
+	}
+	defer file.Close()
+	var scanner = bufio.NewScanner(
+		file,
+	)
+	scanner.Buffer(
+		make([]byte, 64*1024),
+		64*1024*1024,
+	)
+	for line := 1; scanner.Scan(); line++ {
+		var content = bytes.TrimSpace(
+			scanner.Bytes(),
+		)
+		if len(content) == 0 {
+			continue
+		}
+		var entry = &HistoryEntry{}
+		var unmarshalError = json.Unmarshal(
+			content,
+			entry,
+		)
+		if unmarshalError != nil {
+			return nil, fmt.Errorf(
+				"Invalid history entry at line [%v] of file [%v]: %w",
+				line,
+				path,
+				unmarshalError,
+			)
+		}
+		if filter == nil ||
+			filter(entry) {
+			entries = append(
+				entries,
+				entry,
+			)
+		}
+	}
+	var scanError = scanner.Err()
+	if scanError != nil {
+		return nil, scanError
+	}
+	return entries, nil
+}
+
+// ReadHistory replays the entries persisted by the file history store at the given path, including all its rotated files, from the oldest to the latest; only entries matching the given filter are returned, or all entries if the filter is nil
+func ReadHistory(path string, filter func(entry *HistoryEntry) bool) ([]*HistoryEntry, error) {
+	var paths = []string{path}
+	for index := 1; ; index++ {
+		var backupPath = getHistoryBackupPath(
+			path,
+			index,
+		)
+		var _, statError = os.Stat(
+			backupPath,
+		)
+		if statError != nil {
+			break
+		}
+		paths = append(
+			[]string{backupPath},
+			paths...,
+		)
+	}
+	var entries = []*HistoryEntry{}
+	for _, filePath := range paths {
+		var fileEntries, readError = readHistoryFile(
+			filePath,
+			filter,
+		)
+		if readError != nil {
+			return nil, readError
+		}
+		entries = append(
+			entries,
+			fileEntries...,
+		)
+	}
+	return entries, nil
+}
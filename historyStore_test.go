@@ -0,0 +1,632 @@
+package jobrunner
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewFileHistoryStore(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyMaxSize = rand.Int64N(65535)
+	var dummyMaxBackups = rand.IntN(100)
+
+	// SUT + act
+	var result = NewFileHistoryStore(
+		dummyPath,
+		dummyMaxSize,
+		dummyMaxBackups,
+	)
+	var store, ok = result.(*fileHistoryStore)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyPath, store.path)
+	assert.Equal(t, dummyMaxSize, store.maxSize)
+	assert.Equal(t, dummyMaxBackups, store.maxBackups)
+}
+
+func TestFileHistoryStore_SaveInstance(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{path: "some path"}
+	var dummyRoundID = uuid.New()
+	var dummyRecord = &InstanceRecord{Index: rand.IntN(100)}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(writeHistoryEntry).Expects(dummyStore, &HistoryEntry{
+		Timestamp: dummyTimeNow.UTC(),
+		RoundID:   dummyRoundID,
+		Instance:  dummyRecord,
+	}).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = dummyStore.SaveInstance(
+		dummyRoundID,
+		dummyRecord,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestFileHistoryStore_SaveRound(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{path: "some path"}
+	var dummyRecord = &RoundRecord{ID: uuid.New()}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(writeHistoryEntry).Expects(dummyStore, &HistoryEntry{
+		Timestamp: dummyTimeNow.UTC(),
+		RoundID:   dummyRecord.ID,
+		Round:     dummyRecord,
+	}).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = dummyStore.SaveRound(
+		dummyRecord,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestGetHistoryBackupPath(t *testing.T) {
+	// SUT + act
+	var result = getHistoryBackupPath(
+		"some path",
+		3,
+	)
+
+	// assert
+	assert.Equal(t, "some path.3", result)
+}
+
+func TestRenameHistoryFile_NotExist(t *testing.T) {
+	// arrange
+	var dummyFolder = t.TempDir()
+
+	// SUT + act
+	var err = renameHistoryFile(
+		filepath.Join(dummyFolder, "some old path"),
+		filepath.Join(dummyFolder, "some new path"),
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestRenameHistoryFile_Error(t *testing.T) {
+	// arrange
+	var dummyOldPath = "some old path"
+	var dummyNewPath = "some new path"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Rename).Expects(dummyOldPath, dummyNewPath).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = renameHistoryFile(
+		dummyOldPath,
+		dummyNewPath,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestRenameHistoryFile_Success(t *testing.T) {
+	// arrange
+	var dummyFolder = t.TempDir()
+	var dummyOldPath = filepath.Join(dummyFolder, "some old path")
+	var dummyNewPath = filepath.Join(dummyFolder, "some new path")
+
+	// stub
+	os.WriteFile(dummyOldPath, []byte("some content"), 0644)
+
+	// SUT + act
+	var err = renameHistoryFile(
+		dummyOldPath,
+		dummyNewPath,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var content, _ = os.ReadFile(dummyNewPath)
+	assert.Equal(t, "some content", string(content))
+}
+
+func TestRotateHistoryFile_RotationDisabled(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:    "some path",
+		maxSize: 0,
+	}
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		rand.Int64N(100),
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestRotateHistoryFile_NotExist(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:    filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize: 1,
+	}
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		rand.Int64N(100),
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestRotateHistoryFile_StatError(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:    "some path",
+		maxSize: 1,
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Stat).Expects(dummyStore.path).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		rand.Int64N(100),
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestRotateHistoryFile_EmptyFile(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:    filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize: 1,
+	}
+
+	// stub
+	os.WriteFile(dummyStore.path, []byte{}, 0644)
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		100,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.FileExists(t, dummyStore.path)
+}
+
+func TestRotateHistoryFile_WithinSize(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:    filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize: 10,
+	}
+
+	// stub
+	os.WriteFile(dummyStore.path, []byte("12345"), 0644)
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		5,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.FileExists(t, dummyStore.path)
+}
+
+func TestRotateHistoryFile_NoBackups(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:       filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize:    10,
+		maxBackups: 0,
+	}
+
+	// stub
+	os.WriteFile(dummyStore.path, []byte("12345"), 0644)
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		6,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoFileExists(t, dummyStore.path)
+	assert.NoFileExists(t, dummyStore.path+".1")
+}
+
+func TestRotateHistoryFile_RemoveError(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:       filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize:    10,
+		maxBackups: 2,
+	}
+	var dummyError = errors.New("some error")
+
+	// stub
+	os.WriteFile(dummyStore.path, []byte("12345"), 0644)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Remove).Expects(dummyStore.path + ".2").Returns(dummyError).Once()
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		6,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestRotateHistoryFile_RenameError(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:       filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize:    10,
+		maxBackups: 2,
+	}
+	var dummyError = errors.New("some error")
+
+	// stub
+	os.WriteFile(dummyStore.path, []byte("12345"), 0644)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(renameHistoryFile).Expects(dummyStore.path+".1", dummyStore.path+".2").Returns(dummyError).Once()
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		6,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestRotateHistoryFile_Rotated(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path:       filepath.Join(t.TempDir(), "history.jsonl"),
+		maxSize:    10,
+		maxBackups: 2,
+	}
+
+	// stub
+	os.WriteFile(dummyStore.path, []byte("current"), 0644)
+	os.WriteFile(dummyStore.path+".1", []byte("backup 1"), 0644)
+	os.WriteFile(dummyStore.path+".2", []byte("backup 2"), 0644)
+
+	// SUT + act
+	var err = rotateHistoryFile(
+		dummyStore,
+		6,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoFileExists(t, dummyStore.path)
+	var backup1, _ = os.ReadFile(dummyStore.path + ".1")
+	assert.Equal(t, "current", string(backup1))
+	var backup2, _ = os.ReadFile(dummyStore.path + ".2")
+	assert.Equal(t, "backup 1", string(backup2))
+	assert.NoFileExists(t, dummyStore.path+".3")
+}
+
+func TestWriteHistoryEntry_MarshalError(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{path: "some path"}
+	var dummyEntry = &HistoryEntry{RoundID: uuid.New()}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(json.Marshal).Expects(dummyEntry).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var err = writeHistoryEntry(
+		dummyStore,
+		dummyEntry,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteHistoryEntry_RotateError(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{path: "some path"}
+	var dummyEntry = &HistoryEntry{RoundID: uuid.New()}
+	var dummyContent = []byte("some content")
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(json.Marshal).Expects(dummyEntry).Returns(dummyContent, nil).Once()
+	m.Mock(rotateHistoryFile).Expects(dummyStore, int64(len(dummyContent)+1)).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = writeHistoryEntry(
+		dummyStore,
+		dummyEntry,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteHistoryEntry_OpenError(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path: filepath.Join(t.TempDir(), "some folder", "history.jsonl"),
+	}
+	var dummyEntry = &HistoryEntry{RoundID: uuid.New()}
+
+	// SUT + act
+	var err = writeHistoryEntry(
+		dummyStore,
+		dummyEntry,
+	)
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestWriteHistoryEntry_Success(t *testing.T) {
+	// arrange
+	var dummyStore = &fileHistoryStore{
+		path: filepath.Join(t.TempDir(), "history.jsonl"),
+	}
+	var dummyRoundID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	var dummyEntry = &HistoryEntry{
+		Timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		RoundID:   dummyRoundID,
+		Instance: &InstanceRecord{
+			Index:   1,
+			Outcome: InstanceOutcomeSuccess,
+		},
+	}
+
+	// SUT + act
+	var err1 = writeHistoryEntry(
+		dummyStore,
+		dummyEntry,
+	)
+	var err2 = writeHistoryEntry(
+		dummyStore,
+		dummyEntry,
+	)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	var content, _ = os.ReadFile(dummyStore.path)
+	var line = `{"timestamp":"2021-01-01T00:00:00Z","roundId":"00000000-0000-0000-0000-000000000001","instance":{"index":1,"reruns":0,"startTime":"0001-01-01T00:00:00Z","endTime":"0001-01-01T00:00:00Z","duration":0,"outcome":"Success"}}` + "\n"
+	assert.Equal(t, line+line, string(content))
+}
+
+func TestReadHistoryFile_NotExist(t *testing.T) {
+	// SUT + act
+	var result, err = readHistoryFile(
+		filepath.Join(t.TempDir(), "history.jsonl"),
+		nil,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+	assert.NoError(t, err)
+}
+
+func TestReadHistoryFile_OpenError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Open).Expects(dummyPath).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var result, err = readHistoryFile(
+		dummyPath,
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestReadHistoryFile_InvalidEntry(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "history.jsonl")
+
+	// stub
+	os.WriteFile(dummyPath, []byte("{}\nsome invalid entry\n"), 0644)
+
+	// SUT + act
+	var result, err = readHistoryFile(
+		dummyPath,
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.ErrorContains(t, err, "Invalid history entry at line [2] of file")
+}
+
+func TestReadHistoryFile_ScanError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "history.jsonl")
+	var dummyError = errors.New("some error")
+
+	// stub
+	os.WriteFile(dummyPath, []byte("{}\n"), 0644)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*bufio.Scanner).Err).Expects(gomocker.Anything()).Returns(dummyError).Once()
+
+	// SUT + act
+	var result, err = readHistoryFile(
+		dummyPath,
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestReadHistoryFile_Filtered(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "history.jsonl")
+	var dummyRoundID1 = uuid.New()
+	var dummyRoundID2 = uuid.New()
+
+	// stub
+	os.WriteFile(dummyPath, []byte(
+		`{"roundId":"`+dummyRoundID1.String()+`","instance":{"index":0,"outcome":"Failure","error":{"phase":"Action","error":"some error"}}}`+"\n"+
+			"\n"+
+			`{"roundId":"`+dummyRoundID2.String()+`","instance":{"index":1}}`+"\n"+
+			`{"roundId":"`+dummyRoundID1.String()+`","round":{"id":"`+dummyRoundID1.String()+`","trigger":"Manual"}}`+"\n",
+	), 0644)
+
+	// SUT + act
+	var result, err = readHistoryFile(
+		dummyPath,
+		func(entry *HistoryEntry) bool {
+			return entry.RoundID == dummyRoundID1
+		},
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, InstanceOutcomeFailure, result[0].Instance.Outcome)
+	assert.Equal(t, RunPhaseAction, result[0].Instance.Err.Phase)
+	assert.EqualError(t, result[0].Instance.Err.Err, "some error")
+	assert.Nil(t, result[0].Round)
+	assert.Equal(t, TriggerSourceManual, result[1].Round.Trigger)
+	assert.Nil(t, result[1].Instance)
+}
+
+func TestReadHistory_ReadError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "history.jsonl")
+	var dummyError = errors.New("some error")
+
+	// stub
+	os.WriteFile(dummyPath+".1", []byte("{}\n"), 0644)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(readHistoryFile).Expects(dummyPath+".1", nil).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var result, err = ReadHistory(
+		dummyPath,
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestReadHistory_WithRotation(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "history.jsonl")
+	var dummyStore = NewFileHistoryStore(dummyPath, 300, 2)
+	var dummyRecords = []*RoundRecord{}
+
+	// stub
+	for count := 0; count < 5; count++ {
+		var record = &RoundRecord{ID: uuid.New()}
+		dummyRecords = append(dummyRecords, record)
+		dummyStore.SaveRound(record)
+	}
+
+	// SUT + act
+	var result, err = ReadHistory(
+		dummyPath,
+		nil,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.FileExists(t, dummyPath+".2")
+	assert.NoFileExists(t, dummyPath+".3")
+	assert.NotEmpty(t, result)
+	assert.Less(t, len(result), len(dummyRecords))
+	var offset = len(dummyRecords) - len(result)
+	for index, entry := range result {
+		assert.Equal(t, dummyRecords[offset+index].ID, entry.Round.ID)
+	}
+}
@@ -0,0 +1,539 @@
+package jobrunner
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewInstanceRecord_Success(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+
+	// SUT + act
+	var result = newInstanceRecord(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummyStartTime,
+		dummyDuration,
+		RunPhasePostAction,
+		nil,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyIndex, result.Index)
+	assert.Equal(t, dummyReruns, result.Reruns)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+	assert.Equal(t, dummyStartTime.Add(dummyDuration), result.EndTime)
+	assert.Equal(t, dummyDuration, result.Duration)
+	assert.Equal(t, InstanceOutcomeSuccess, result.Outcome)
+	assert.Nil(t, result.Err)
+}
+
+func TestNewInstanceRecord_Failure(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{Err: dummyError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRunError).Expects(dummyRound, dummyIndex, dummyReruns, RunPhaseAction, dummyError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var result = newInstanceRecord(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummyStartTime,
+		dummyDuration,
+		RunPhaseAction,
+		dummyError,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, InstanceOutcomeFailure, result.Outcome)
+	assert.Equal(t, dummyRunError, result.Err)
+}
+
+func TestNewInstanceRecord_Panic(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{Err: dummyError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRunError).Expects(dummyRound, dummyIndex, dummyReruns, RunPhasePanic, dummyError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var result = newInstanceRecord(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummyStartTime,
+		dummyDuration,
+		RunPhasePanic,
+		dummyError,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, InstanceOutcomePanic, result.Outcome)
+	assert.Equal(t, dummyRunError, result.Err)
+}
+
+func TestNewInstanceRecord_Timeout(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{Err: dummyError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newRunError).Expects(dummyRound, dummyIndex, dummyReruns, RunPhaseTimeout, dummyError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var result = newInstanceRecord(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummyStartTime,
+		dummyDuration,
+		RunPhaseTimeout,
+		dummyError,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, InstanceOutcomeTimeout, result.Outcome)
+	assert.Equal(t, dummyRunError, result.Err)
+}
+
+func TestNewWorkItemRecord_Success(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummySequence = rand.IntN(100)
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+
+	// SUT + act
+	var result = newWorkItemRecord(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummySequence,
+		dummyStartTime,
+		dummyDuration,
+		RunPhasePostAction,
+		nil,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummySequence, result.Sequence)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+	assert.Equal(t, dummyStartTime.Add(dummyDuration), result.EndTime)
+	assert.Equal(t, dummyDuration, result.Duration)
+	assert.Equal(t, InstanceOutcomeSuccess, result.Outcome)
+	assert.Nil(t, result.Err)
+}
+
+func TestNewWorkItemRecord_Failure(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummySequence = rand.IntN(100)
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+	var dummyError = errors.New("some error")
+
+	// SUT + act
+	var result = newWorkItemRecord(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummySequence,
+		dummyStartTime,
+		dummyDuration,
+		RunPhaseConsumeWork,
+		dummyError,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummySequence, result.Sequence)
+	assert.Equal(t, InstanceOutcomeFailure, result.Outcome)
+	assert.NotNil(t, result.Err)
+	assert.Equal(t, dummyRound.id, result.Err.RoundID)
+	assert.Equal(t, dummyIndex, result.Err.Index)
+	assert.Equal(t, dummyReruns, result.Err.Reruns)
+	assert.Equal(t, dummySequence, result.Err.Item)
+	assert.Equal(t, RunPhaseConsumeWork, result.Err.Phase)
+	assert.Equal(t, dummyError, result.Err.Err)
+}
+
+func TestNewRoundRecord_Completed(t *testing.T) {
+	// arrange
+	var dummyRound = &round{
+		id:        uuid.New(),
+		trigger:   TriggerSourceManual,
+		reason:    "some reason",
+		scheduled: time.Now(),
+	}
+	var dummyStartTime = time.Now()
+	var dummyInstances = []*InstanceRecord{
+		{Index: 0},
+		{Index: 1},
+	}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+
+	// SUT + act
+	var result = newRoundRecord(
+		dummyRound,
+		dummyStartTime,
+		dummyInstances,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyRound.id, result.ID)
+	assert.Equal(t, dummyRound.trigger, result.Trigger)
+	assert.Equal(t, dummyRound.reason, result.Reason)
+	assert.Equal(t, dummyRound.scheduled, result.ScheduledTime)
+	assert.Equal(t, RoundStatusCompleted, result.Status)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+	assert.Equal(t, dummyTimeNow.UTC(), result.EndTime)
+	assert.False(t, result.Failed)
+	assert.Equal(t, dummyInstances, result.Instances)
+}
+
+func TestNewRoundRecord_Cancelled(t *testing.T) {
+	// arrange
+	var dummyRound = &round{
+		id:        uuid.New(),
+		trigger:   TriggerSourceManual,
+		reason:    "some reason",
+		scheduled: time.Now(),
+	}
+	var dummyStartTime = time.Now()
+
+	// stub
+	dummyRound.cancelled.Store(true)
+	dummyRound.failed.Store(true)
+	var dummyInstances = []*InstanceRecord{
+		{Index: 0},
+		{Index: 1},
+	}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+
+	// SUT + act
+	var result = newRoundRecord(
+		dummyRound,
+		dummyStartTime,
+		dummyInstances,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyRound.id, result.ID)
+	assert.Equal(t, dummyRound.trigger, result.Trigger)
+	assert.Equal(t, dummyRound.reason, result.Reason)
+	assert.Equal(t, dummyRound.scheduled, result.ScheduledTime)
+	assert.Equal(t, RoundStatusCancelled, result.Status)
+	assert.True(t, result.Failed)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+	assert.Equal(t, dummyTimeNow.UTC(), result.EndTime)
+	assert.Equal(t, dummyInstances, result.Instances)
+}
+
+func TestNewDroppedRoundRecord(t *testing.T) {
+	// arrange
+	var dummyRound = &round{
+		id:        uuid.New(),
+		trigger:   TriggerSourceScheduled,
+		scheduled: time.Now(),
+	}
+	var dummyStatus = RoundStatus(rand.IntN(4))
+
+	// SUT + act
+	var result = newDroppedRoundRecord(
+		dummyRound,
+		dummyStatus,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyRound.id, result.ID)
+	assert.Equal(t, dummyRound.trigger, result.Trigger)
+	assert.Equal(t, dummyRound.reason, result.Reason)
+	assert.Equal(t, dummyRound.scheduled, result.ScheduledTime)
+	assert.Equal(t, dummyStatus, result.Status)
+	assert.Zero(t, result.StartTime)
+	assert.Zero(t, result.EndTime)
+	assert.Empty(t, result.Instances)
+}
+
+func TestNewRoundHistory_InvalidRetention(t *testing.T) {
+	// SUT + act
+	var result = newRoundHistory(0)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, 1, result.retention)
+	assert.Empty(t, result.records)
+}
+
+func TestNewRoundHistory_ValidRetention(t *testing.T) {
+	// arrange
+	var dummyRetention = rand.IntN(100) + 1
+
+	// SUT + act
+	var result = newRoundHistory(dummyRetention)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyRetention, result.retention)
+	assert.Empty(t, result.records)
+}
+
+func TestRoundHistory_Add(t *testing.T) {
+	// arrange
+	var dummyRecords = []*RoundRecord{
+		{ID: uuid.New()},
+		{ID: uuid.New()},
+		{ID: uuid.New()},
+	}
+	var sut = newRoundHistory(2)
+
+	// act
+	for _, record := range dummyRecords {
+		sut.add(record)
+	}
+
+	// assert
+	assert.Equal(t, dummyRecords[1:], sut.records)
+}
+
+func TestRoundHistory_List(t *testing.T) {
+	// arrange
+	var dummyRecords = []*RoundRecord{
+		{ID: uuid.New()},
+		{ID: uuid.New()},
+	}
+	var sut = newRoundHistory(10)
+
+	// stub
+	for _, record := range dummyRecords {
+		sut.add(record)
+	}
+
+	// act
+	var result = sut.list()
+	result[0] = nil
+
+	// assert
+	assert.Equal(t, dummyRecords, sut.records)
+}
+
+func TestRecordInstance_NoStore(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRecord = &InstanceRecord{Index: rand.IntN(100)}
+
+	// SUT + act
+	recordInstance(
+		dummyApplication,
+		dummyRound,
+		dummyRecord,
+	)
+}
+
+func TestRecordInstance_SaveError(t *testing.T) {
+	// arrange
+	type historyStore struct {
+		HistoryStore
+	}
+	var dummyStore = &historyStore{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		session: dummySession,
+		store:   dummyStore,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRecord = &InstanceRecord{Index: rand.IntN(100)}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*historyStore).SaveInstance).Expects(dummyStore, dummyRound.id, dummyRecord).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "history", "recordInstance",
+		"Failed to save instance [%v] of round [%v] to history store. Error: %+v",
+		dummyRecord.Index, dummyRound.id, dummyError).Returns().Once()
+
+	// SUT + act
+	recordInstance(
+		dummyApplication,
+		dummyRound,
+		dummyRecord,
+	)
+}
+
+func TestRecordInstance_Success(t *testing.T) {
+	// arrange
+	type historyStore struct {
+		HistoryStore
+	}
+	var dummyStore = &historyStore{}
+	var dummyApplication = &application{
+		store: dummyStore,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyRecord = &InstanceRecord{Index: rand.IntN(100)}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*historyStore).SaveInstance).Expects(dummyStore, dummyRound.id, dummyRecord).Returns(nil).Once()
+
+	// SUT + act
+	recordInstance(
+		dummyApplication,
+		dummyRound,
+		dummyRecord,
+	)
+}
+
+func TestRecordRound_NoStore(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		history: newRoundHistory(10),
+	}
+	var dummyRecord = &RoundRecord{ID: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(notifyDownstreams).Expects(dummyApplication, dummyRecord).Returns().Once()
+	m.Mock(saveSlot).Expects(dummyApplication, dummyRecord).Returns().Once()
+
+	// SUT + act
+	recordRound(
+		dummyApplication,
+		dummyRecord,
+	)
+
+	// assert
+	assert.Equal(t, []*RoundRecord{dummyRecord}, dummyApplication.History())
+}
+
+func TestRecordRound_SaveError(t *testing.T) {
+	// arrange
+	type historyStore struct {
+		HistoryStore
+	}
+	var dummyStore = &historyStore{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		session: dummySession,
+		history: newRoundHistory(10),
+		store:   dummyStore,
+	}
+	var dummyRecord = &RoundRecord{ID: uuid.New()}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*historyStore).SaveRound).Expects(dummyStore, dummyRecord).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "history", "recordRound",
+		"Failed to save round [%v] to history store. Error: %+v", dummyRecord.ID, dummyError).Returns().Once()
+
+	// SUT + act
+	recordRound(
+		dummyApplication,
+		dummyRecord,
+	)
+
+	// assert
+	assert.Equal(t, []*RoundRecord{dummyRecord}, dummyApplication.History())
+}
+
+func TestRecordRound_Success(t *testing.T) {
+	// arrange
+	type historyStore struct {
+		HistoryStore
+	}
+	var dummyStore = &historyStore{}
+	var dummyApplication = &application{
+		history: newRoundHistory(10),
+		store:   dummyStore,
+	}
+	var dummyRecord = &RoundRecord{ID: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*historyStore).SaveRound).Expects(dummyStore, dummyRecord).Returns(nil).Once()
+
+	// SUT + act
+	recordRound(
+		dummyApplication,
+		dummyRecord,
+	)
+
+	// assert
+	assert.Equal(t, []*RoundRecord{dummyRecord}, dummyApplication.History())
+}
@@ -0,0 +1,67 @@
+package jobrunner
+
+// InstanceOutcome is the outcome of the execution of a single instance
+type InstanceOutcome int
+
+// These are the enum definitions of instance outcomes
+const (
+	InstanceOutcomeSuccess InstanceOutcome = iota
+	InstanceOutcomeFailure
+	InstanceOutcomePanic
+	InstanceOutcomeTimeout
+	InstanceOutcomeLocked
+)
+
+// These are the string representations of instance outcomes
+const (
+	successInstanceOutcomeName string = "Success"
+	failureInstanceOutcomeName string = "Failure"
+	panicInstanceOutcomeName   string = "Panic"
+	timeoutInstanceOutcomeName string = "Timeout"
+	lockedInstanceOutcomeName  string = "Locked"
+)
+
+var supportedInstanceOutcomes = map[InstanceOutcome]string{
+	InstanceOutcomeSuccess: successInstanceOutcomeName,
+	InstanceOutcomeFailure: failureInstanceOutcomeName,
+	InstanceOutcomePanic:   panicInstanceOutcomeName,
+	InstanceOutcomeTimeout: timeoutInstanceOutcomeName,
+	InstanceOutcomeLocked:  lockedInstanceOutcomeName,
+}
+
+var instanceOutcomeNameMapping = map[string]InstanceOutcome{
+	successInstanceOutcomeName: InstanceOutcomeSuccess,
+	failureInstanceOutcomeName: InstanceOutcomeFailure,
+	panicInstanceOutcomeName:   InstanceOutcomePanic,
+	timeoutInstanceOutcomeName: InstanceOutcomeTimeout,
+	lockedInstanceOutcomeName:  InstanceOutcomeLocked,
+}
+
+// String converts an InstanceOutcome instance to its string representation
+func (instanceOutcome InstanceOutcome) String() string {
+	var name, found = supportedInstanceOutcomes[instanceOutcome]
+	if !found {
+		return successInstanceOutcomeName
+	}
+	return name
+}
+
+// NewInstanceOutcome converts a string representation of InstanceOutcome to its strongly typed instance
+func NewInstanceOutcome(value string) InstanceOutcome {
+	var instanceOutcome, found = instanceOutcomeNameMapping[value]
+	if !found {
+		return InstanceOutcomeSuccess
+	}
+	return instanceOutcome
+}
+
+// MarshalText converts an InstanceOutcome instance to its string representation for encodings like JSON
+func (instanceOutcome InstanceOutcome) MarshalText() ([]byte, error) {
+	return []byte(instanceOutcome.String()), nil
+}
+
+// UnmarshalText converts a string representation of InstanceOutcome from encodings like JSON to its strongly typed instance
+func (instanceOutcome *InstanceOutcome) UnmarshalText(text []byte) error {
+	*instanceOutcome = NewInstanceOutcome(string(text))
+	return nil
+}
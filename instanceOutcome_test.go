@@ -0,0 +1,74 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceOutcomeString_NonSupportedInstanceOutcome(t *testing.T) {
+	// SUT
+	var sut = InstanceOutcome(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, successInstanceOutcomeName, result)
+}
+
+func TestInstanceOutcomeString_SupportedInstanceOutcome(t *testing.T) {
+	// SUT
+	var sut = InstanceOutcomePanic
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, panicInstanceOutcomeName, result)
+}
+
+func TestNewInstanceOutcome_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewInstanceOutcome(dummyValue)
+
+	// assert
+	assert.Equal(t, InstanceOutcomeSuccess, result)
+}
+
+func TestNewInstanceOutcome_HappyPath(t *testing.T) {
+	for key, value := range instanceOutcomeNameMapping {
+		// SUT + act
+		var result = NewInstanceOutcome(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
+
+func TestInstanceOutcomeMarshalText(t *testing.T) {
+	// SUT
+	var sut = InstanceOutcomeFailure
+
+	// act
+	var result, err = sut.MarshalText()
+
+	// assert
+	assert.Equal(t, []byte("Failure"), result)
+	assert.NoError(t, err)
+}
+
+func TestInstanceOutcomeUnmarshalText(t *testing.T) {
+	// SUT
+	var sut InstanceOutcome
+
+	// act
+	var err = sut.UnmarshalText([]byte("Failure"))
+
+	// assert
+	assert.Equal(t, InstanceOutcomeFailure, sut)
+	assert.NoError(t, err)
+}
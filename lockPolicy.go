@@ -0,0 +1,219 @@
+package jobrunner
+
+import (
+	"fmt"
+	"time"
+)
+
+// LockPolicy is the policy of locking the scheduled rounds through a Locker, so that each scheduled slot is executed by only one of the replicas of the same job runner
+type LockPolicy struct {
+	// Locker is the locker acquiring the leases, e.g. NewFileLocker; nil means no locking
+	Locker Locker
+	// TTL is how long a lease lasts unless renewed, and the least it is held for; 0 or negative means no locking
+	TTL time.Duration
+	// PerInstance is whether each instance of a scheduled round is locked individually instead of the whole round, spreading the instances of the same slot across the replicas
+	PerInstance bool
+}
+
+// isLocking returns true if the round is a scheduled or catch-up one to be locked, either as a whole or per instance as given
+func isLocking(app *application, round *round, perInstance bool) bool {
+	return !isInterfaceValueNil(app.locking.Locker) &&
+		app.locking.TTL > 0 &&
+		app.locking.PerInstance == perInstance &&
+		(round.trigger == TriggerSourceScheduled || round.trigger == TriggerSourceCatchUp)
+}
+
+// getLeaseKey returns the key identifying the scheduled slot of the round, or the instance of the given index within it, across the replicas
+func getLeaseKey(app *application, round *round, index int) string {
+	if index < 0 {
+		return fmt.Sprintf(
+			"%v-%v",
+			app.name,
+			round.scheduled.UnixNano(),
+		)
+	}
+	return fmt.Sprintf(
+		"%v-%v-%v",
+		app.name,
+		round.scheduled.UnixNano(),
+		index,
+	)
+}
+
+func releaseLease(app *application, key string, lease Lease) {
+	var releaseError = lease.Release()
+	if releaseError != nil {
+		logAppRoot(
+			app.session,
+			"lockPolicy",
+			"releaseLease",
+			"Failed to release lease [%v]: %+v",
+			key,
+			releaseError,
+		)
+	}
+}
+
+// holdLease renews the lease every third of the TTL until the returned function is called, which then releases the lease once the TTL since acquired has elapsed
+func holdLease(app *application, key string, lease Lease) func() {
+	var acquiredAt = time.Now()
+	var done = make(chan struct{})
+	var stopped = make(chan struct{})
+	go func() {
+		defer close(stopped)
+		var ticker = time.NewTicker(app.locking.TTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			var renewError = lease.Renew(
+				app.locking.TTL,
+			)
+			if renewError != nil {
+				logAppRoot(
+					app.session,
+					"lockPolicy",
+					"holdLease",
+					"Failed to renew lease [%v], which could be taken over by another replica: %+v",
+					key,
+					renewError,
+				)
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+		time.AfterFunc(
+			app.locking.TTL-time.Since(acquiredAt),
+			func() {
+				releaseLease(
+					app,
+					key,
+					lease,
+				)
+			},
+		)
+	}
+}
+
+// acquireLease acquires the lease of the scheduled slot of the round, or of the instance of the given index within it, and returns the function to release it; returns false if the lease is held by another replica or could not be acquired
+func acquireLease(app *application, round *round, index int) (func(), bool, error) {
+	var key = getLeaseKey(
+		app,
+		round,
+		index,
+	)
+	var lease, acquireError = app.locking.Locker.Acquire(
+		key,
+		app.locking.TTL,
+	)
+	if acquireError != nil {
+		logAppRoot(
+			app.session,
+			"lockPolicy",
+			"acquireLease",
+			"Failed to acquire lease [%v]: %+v",
+			key,
+			acquireError,
+		)
+		return nil, false, acquireError
+	}
+	if lease == nil {
+		logAppRoot(
+			app.session,
+			"lockPolicy",
+			"acquireLease",
+			"Lease [%v] is held by another replica, skipping execution",
+			key,
+		)
+		return nil, false, nil
+	}
+	return holdLease(
+		app,
+		key,
+		lease,
+	), true, nil
+}
+
+// lockRound acquires the lease of the scheduled slot of the round when locking whole rounds, and returns the function to release it; returns false if the round is not to be executed by this replica
+func lockRound(app *application, round *round) (func(), bool) {
+	if !isLocking(
+		app,
+		round,
+		false,
+	) {
+		return func() {}, true
+	}
+	var release, acquired, acquireError = acquireLease(
+		app,
+		round,
+		-1,
+	)
+	if acquireError != nil {
+		recordError(
+			app,
+			newRunError(
+				round,
+				-1,
+				0,
+				RunPhaseLock,
+				acquireError,
+			),
+		)
+	}
+	return release, acquired
+}
+
+// handleLockedSession handles the session of the instance once acquired its lease when locking per instance, and returns the record of the instance execution; an instance held by another replica is recorded as locked
+func handleLockedSession(
+	app *application,
+	round *round,
+	index int,
+	reruns int,
+) *InstanceRecord {
+	if !isLocking(
+		app,
+		round,
+		true,
+	) {
+		return handleSession(
+			app,
+			round,
+			index,
+			reruns,
+		)
+	}
+	var startTime = time.Now().UTC()
+	var release, acquired, acquireError = acquireLease(
+		app,
+		round,
+		index,
+	)
+	if !acquired {
+		var record = newInstanceRecord(
+			round,
+			index,
+			reruns,
+			startTime,
+			time.Since(startTime),
+			RunPhaseLock,
+			acquireError,
+		)
+		if acquireError == nil {
+			record.Outcome = InstanceOutcomeLocked
+		}
+		return record
+	}
+	defer release()
+	return handleSession(
+		app,
+		round,
+		index,
+		reruns,
+	)
+}
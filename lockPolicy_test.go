@@ -0,0 +1,622 @@
+package jobrunner
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestIsLocking_NoLocker(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		locking: LockPolicy{TTL: time.Minute},
+	}
+	var dummyRound = &round{trigger: TriggerSourceScheduled}
+
+	// SUT + act
+	var result = isLocking(
+		dummyApplication,
+		dummyRound,
+		false,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLocking_TypedNilLocker(t *testing.T) {
+	// arrange
+	var dummyLocker *fileLocker
+	var dummyApplication = &application{
+		locking: LockPolicy{Locker: dummyLocker, TTL: time.Minute},
+	}
+	var dummyRound = &round{trigger: TriggerSourceScheduled}
+
+	// SUT + act
+	var result = isLocking(
+		dummyApplication,
+		dummyRound,
+		false,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLocking_NoTTL(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	var dummyApplication = &application{
+		locking: LockPolicy{Locker: &locker{}},
+	}
+	var dummyRound = &round{trigger: TriggerSourceScheduled}
+
+	// SUT + act
+	var result = isLocking(
+		dummyApplication,
+		dummyRound,
+		false,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLocking_OtherScope(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	var dummyApplication = &application{
+		locking: LockPolicy{Locker: &locker{}, TTL: time.Minute, PerInstance: true},
+	}
+	var dummyRound = &round{trigger: TriggerSourceScheduled}
+
+	// SUT + act
+	var result = isLocking(
+		dummyApplication,
+		dummyRound,
+		false,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLocking_NotScheduled(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	var dummyApplication = &application{
+		locking: LockPolicy{Locker: &locker{}, TTL: time.Minute, PerInstance: true},
+	}
+	var dummyRound = &round{trigger: TriggerSourceManual}
+
+	// SUT + act
+	var result = isLocking(
+		dummyApplication,
+		dummyRound,
+		true,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLocking_Locking(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	var dummyApplication = &application{
+		locking: LockPolicy{Locker: &locker{}, TTL: time.Minute, PerInstance: true},
+	}
+	var dummyRound = &round{trigger: TriggerSourceScheduled}
+
+	// SUT + act
+	var result = isLocking(
+		dummyApplication,
+		dummyRound,
+		true,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestGetLeaseKey_Round(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRound = &round{scheduled: time.Unix(12, 345)}
+
+	// SUT + act
+	var result = getLeaseKey(
+		dummyApplication,
+		dummyRound,
+		-1,
+	)
+
+	// assert
+	assert.Equal(t, "some name-12000000345", result)
+}
+
+func TestGetLeaseKey_Instance(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyRound = &round{scheduled: time.Unix(12, 345)}
+
+	// SUT + act
+	var result = getLeaseKey(
+		dummyApplication,
+		dummyRound,
+		3,
+	)
+
+	// assert
+	assert.Equal(t, "some name-12000000345-3", result)
+}
+
+func TestReleaseLease_Error(t *testing.T) {
+	// arrange
+	type lease struct {
+		Lease
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{session: dummySession}
+	var dummyKey = "some key"
+	var dummyLease = &lease{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*lease).Release).Expects(dummyLease).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "lockPolicy", "releaseLease",
+		"Failed to release lease [%v]: %+v", dummyKey, dummyError).Returns().Once()
+
+	// SUT + act
+	releaseLease(
+		dummyApplication,
+		dummyKey,
+		dummyLease,
+	)
+}
+
+func TestReleaseLease_Success(t *testing.T) {
+	// arrange
+	type lease struct {
+		Lease
+	}
+	var dummyApplication = &application{}
+	var dummyKey = "some key"
+	var dummyLease = &lease{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*lease).Release).Expects(dummyLease).Returns(nil).Once()
+
+	// SUT + act
+	releaseLease(
+		dummyApplication,
+		dummyKey,
+		dummyLease,
+	)
+}
+
+func TestHoldLease_RenewFailed(t *testing.T) {
+	// arrange
+	type lease struct {
+		Lease
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyTTL = 30 * time.Millisecond
+	var dummyApplication = &application{
+		session: dummySession,
+		locking: LockPolicy{TTL: dummyTTL},
+	}
+	var dummyKey = "some key"
+	var dummyLease = &lease{}
+	var dummyRenewed = make(chan struct{})
+	var dummyReleased = make(chan struct{})
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*lease).Renew).Expects(dummyLease, dummyTTL).Returns(ErrLeaseLost).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "lockPolicy", "holdLease",
+		"Failed to renew lease [%v], which could be taken over by another replica: %+v",
+		dummyKey, ErrLeaseLost).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() { close(dummyRenewed) })).Once()
+	m.Mock(releaseLease).Expects(dummyApplication, dummyKey, dummyLease).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() { close(dummyReleased) })).Once()
+
+	// SUT + act
+	var startTime = time.Now()
+	var release = holdLease(
+		dummyApplication,
+		dummyKey,
+		dummyLease,
+	)
+	<-dummyRenewed
+	release()
+	<-dummyReleased
+
+	// assert
+	assert.GreaterOrEqual(t, time.Since(startTime), dummyTTL)
+}
+
+func TestHoldLease_Renewed(t *testing.T) {
+	// arrange
+	type lease struct {
+		Lease
+	}
+	var dummyTTL = 600 * time.Millisecond
+	var dummyApplication = &application{
+		locking: LockPolicy{TTL: dummyTTL},
+	}
+	var dummyKey = "some key"
+	var dummyLease = &lease{}
+	var dummyRenewed = make(chan struct{})
+	var dummyReleased = make(chan struct{})
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*lease).Renew).Expects(dummyLease, dummyTTL).Returns(nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { close(dummyRenewed) })).Once()
+	m.Mock(releaseLease).Expects(dummyApplication, dummyKey, dummyLease).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() { close(dummyReleased) })).Once()
+
+	// SUT + act
+	var startTime = time.Now()
+	var release = holdLease(
+		dummyApplication,
+		dummyKey,
+		dummyLease,
+	)
+	<-dummyRenewed
+	release()
+	<-dummyReleased
+
+	// assert
+	assert.GreaterOrEqual(t, time.Since(startTime), dummyTTL)
+}
+
+func TestAcquireLease_Error(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyLocker = &locker{}
+	var dummyTTL = time.Duration(rand.IntN(100))
+	var dummyApplication = &application{
+		session: dummySession,
+		locking: LockPolicy{Locker: dummyLocker, TTL: dummyTTL},
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyKey = "some key"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getLeaseKey).Expects(dummyApplication, dummyRound, dummyIndex).Returns(dummyKey).Once()
+	m.Mock((*locker).Acquire).Expects(dummyLocker, dummyKey, dummyTTL).Returns(nil, dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "lockPolicy", "acquireLease",
+		"Failed to acquire lease [%v]: %+v", dummyKey, dummyError).Returns().Once()
+
+	// SUT + act
+	var release, acquired, err = acquireLease(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+	)
+
+	// assert
+	assert.Nil(t, release)
+	assert.False(t, acquired)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestAcquireLease_Held(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyLocker = &locker{}
+	var dummyTTL = time.Duration(rand.IntN(100))
+	var dummyApplication = &application{
+		session: dummySession,
+		locking: LockPolicy{Locker: dummyLocker, TTL: dummyTTL},
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyKey = "some key"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getLeaseKey).Expects(dummyApplication, dummyRound, dummyIndex).Returns(dummyKey).Once()
+	m.Mock((*locker).Acquire).Expects(dummyLocker, dummyKey, dummyTTL).Returns(nil, nil).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "lockPolicy", "acquireLease",
+		"Lease [%v] is held by another replica, skipping execution", dummyKey).Returns().Once()
+
+	// SUT + act
+	var release, acquired, err = acquireLease(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+	)
+
+	// assert
+	assert.Nil(t, release)
+	assert.False(t, acquired)
+	assert.NoError(t, err)
+}
+
+func TestAcquireLease_Acquired(t *testing.T) {
+	// arrange
+	type locker struct {
+		Locker
+	}
+	type lease struct {
+		Lease
+	}
+	var dummyLocker = &locker{}
+	var dummyTTL = time.Duration(rand.IntN(100))
+	var dummyApplication = &application{
+		locking: LockPolicy{Locker: dummyLocker, TTL: dummyTTL},
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyKey = "some key"
+	var dummyLease = &lease{}
+	var releaseCalled = 0
+	var dummyRelease = func() {
+		releaseCalled++
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getLeaseKey).Expects(dummyApplication, dummyRound, dummyIndex).Returns(dummyKey).Once()
+	m.Mock((*locker).Acquire).Expects(dummyLocker, dummyKey, dummyTTL).Returns(dummyLease, nil).Once()
+	m.Mock(holdLease).Expects(dummyApplication, dummyKey, dummyLease).Returns(dummyRelease).Once()
+
+	// SUT + act
+	var release, acquired, err = acquireLease(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+	)
+	release()
+
+	// assert
+	assert.True(t, acquired)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, releaseCalled)
+}
+
+func TestLockRound_NotLocking(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRound = &round{id: uuid.New()}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, false).Returns(false).Once()
+
+	// SUT + act
+	var release, locked = lockRound(
+		dummyApplication,
+		dummyRound,
+	)
+	release()
+
+	// assert
+	assert.True(t, locked)
+}
+
+func TestLockRound_Error(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		errors: newErrorRing(10),
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{Err: dummyError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, false).Returns(true).Once()
+	m.Mock(acquireLease).Expects(dummyApplication, dummyRound, -1).Returns(nil, false, dummyError).Once()
+	m.Mock(newRunError).Expects(dummyRound, -1, 0, RunPhaseLock, dummyError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var release, locked = lockRound(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Nil(t, release)
+	assert.False(t, locked)
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}
+
+func TestLockRound_Acquired(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		errors: newErrorRing(10),
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var releaseCalled = 0
+	var dummyRelease = func() {
+		releaseCalled++
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, false).Returns(true).Once()
+	m.Mock(acquireLease).Expects(dummyApplication, dummyRound, -1).Returns(dummyRelease, true, nil).Once()
+
+	// SUT + act
+	var release, locked = lockRound(
+		dummyApplication,
+		dummyRound,
+	)
+	release()
+
+	// assert
+	assert.True(t, locked)
+	assert.Equal(t, 1, releaseCalled)
+	assert.Empty(t, dummyApplication.RunErrors())
+}
+
+func TestHandleLockedSession_NotLocking(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyRecord = &InstanceRecord{Index: dummyIndex}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, true).Returns(false).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, dummyIndex, dummyReruns).Returns(dummyRecord).Once()
+
+	// SUT + act
+	var result = handleLockedSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummyRecord, result)
+}
+
+func TestHandleLockedSession_Error(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, true).Returns(true).Once()
+	m.Mock(acquireLease).Expects(dummyApplication, dummyRound, dummyIndex).Returns(nil, false, dummyError).Once()
+
+	// SUT + act
+	var result = handleLockedSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummyIndex, result.Index)
+	assert.Equal(t, dummyReruns, result.Reruns)
+	assert.Equal(t, InstanceOutcomeFailure, result.Outcome)
+	assert.Equal(t, RunPhaseLock, result.Err.Phase)
+	assert.Equal(t, dummyError, result.Err.Err)
+	assert.False(t, dummyRound.failed.Load())
+}
+
+func TestHandleLockedSession_Held(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, true).Returns(true).Once()
+	m.Mock(acquireLease).Expects(dummyApplication, dummyRound, dummyIndex).Returns(nil, false, nil).Once()
+
+	// SUT + act
+	var result = handleLockedSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummyIndex, result.Index)
+	assert.Equal(t, dummyReruns, result.Reruns)
+	assert.Equal(t, InstanceOutcomeLocked, result.Outcome)
+	assert.Nil(t, result.Err)
+	assert.False(t, dummyRound.failed.Load())
+}
+
+func TestHandleLockedSession_Acquired(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyRecord = &InstanceRecord{Index: dummyIndex}
+	var releaseCalled = 0
+	var dummyRelease = func() {
+		releaseCalled++
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLocking).Expects(dummyApplication, dummyRound, true).Returns(true).Once()
+	m.Mock(acquireLease).Expects(dummyApplication, dummyRound, dummyIndex).Returns(dummyRelease, true, nil).Once()
+	m.Mock(handleSession).Expects(dummyApplication, dummyRound, dummyIndex, dummyReruns).Returns(dummyRecord).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { assert.Zero(t, releaseCalled) })).Once()
+
+	// SUT + act
+	var result = handleLockedSession(
+		dummyApplication,
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+	)
+
+	// assert
+	assert.Equal(t, dummyRecord, result)
+	assert.Equal(t, 1, releaseCalled)
+}
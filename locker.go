@@ -0,0 +1,310 @@
+package jobrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrLeaseLost is the error reported when renewing a lease which has been released or taken over by another owner
+var ErrLeaseLost = errors.New("lease lost")
+
+// Locker is the interface for acquiring leases shared across processes, e.g. for the replicas of the same job runner to agree on which one executes a scheduled slot
+type Locker interface {
+	// Acquire tries to acquire the lease of the given key for the given TTL; returns a nil lease without error if the key is held by another owner whose lease has not expired yet
+	Acquire(key string, ttl time.Duration) (Lease, error)
+}
+
+// Lease is the interface of a lease acquired from a Locker, held until released or expired
+type Lease interface {
+	// Renew extends the lease for the given TTL from now; returns ErrLeaseLost if the lease has been released or taken over by another owner after expiry
+	Renew(ttl time.Duration) error
+	// Release gives up the lease, making its key available to others right away; releasing a lost lease does nothing
+	Release() error
+}
+
+// leaseContent is the content of a lease file, identifying its owner and when it expires
+type leaseContent struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type fileLocker struct {
+	directory string
+	owner     string
+	sweptAt   time.Time
+	lock      sync.Mutex
+}
+
+type fileLease struct {
+	path  string
+	owner string
+}
+
+// NewFileLocker creates a locker keeping each lease as a JSON file in the given directory, e.g. on a volume shared by the replicas; an expired lease file, e.g. left behind by a crashed replica, is taken over by the next owner acquiring it or swept upon acquiring any other key
+func NewFileLocker(directory string) Locker {
+	var hostname, _ = os.Hostname()
+	return &fileLocker{
+		directory: directory,
+		owner: fmt.Sprintf(
+			"%v/%v",
+			hostname,
+			os.Getpid(),
+		),
+	}
+}
+
+// Acquire tries to acquire the lease of the given key for the given TTL; returns a nil lease without error if the key is held by another owner whose lease has not expired yet
+func (locker *fileLocker) Acquire(key string, ttl time.Duration) (Lease, error) {
+	sweepLeaseFiles(
+		locker,
+		ttl,
+	)
+	var lease = &fileLease{
+		path: filepath.Join(
+			locker.directory,
+			url.PathEscape(key)+".lease",
+		),
+		owner: fmt.Sprintf(
+			"%v/%v",
+			locker.owner,
+			uuid.New(),
+		),
+	}
+	var content = &leaseContent{
+		Owner:     lease.owner,
+		ExpiresAt: time.Now().Add(ttl).UTC(),
+	}
+	var created, createError = createLeaseFile(
+		lease.path,
+		content,
+	)
+	if createError != nil {
+		return nil, createError
+	}
+	if !created {
+		var takenOver, takeOverError = takeOverLeaseFile(
+			lease.path,
+			content,
+		)
+		if takeOverError != nil {
+			return nil, takeOverError
+		}
+		if !takenOver {
+			return nil, nil
+		}
+	}
+	return lease, nil
+}
+
+// Renew extends the lease for the given TTL from now; returns ErrLeaseLost if the lease has been released or taken over by another owner after expiry
+func (lease *fileLease) Renew(ttl time.Duration) error {
+	var current, readError = readLeaseFile(
+		lease.path,
+	)
+	if readError != nil {
+		if os.IsNotExist(readError) {
+			return ErrLeaseLost
+		}
+		return readError
+	}
+	if current.Owner != lease.owner {
+		return ErrLeaseLost
+	}
+	return writeLeaseFile(
+		lease.path,
+		&leaseContent{
+			Owner:     lease.owner,
+			ExpiresAt: time.Now().Add(ttl).UTC(),
+		},
+		os.Rename,
+	)
+}
+
+// Release gives up the lease, making its key available to others right away; releasing a lost lease does nothing
+func (lease *fileLease) Release() error {
+	var current, readError = readLeaseFile(
+		lease.path,
+	)
+	if readError != nil {
+		if os.IsNotExist(readError) {
+			return nil
+		}
+		return readError
+	}
+	if current.Owner != lease.owner {
+		return nil
+	}
+	var removeError = os.Remove(
+		lease.path,
+	)
+	if removeError != nil &&
+		!os.IsNotExist(removeError) {
+		return removeError
+	}
+	return nil
+}
+
+func readLeaseFile(path string) (*leaseContent, error) {
+	var data, readError = os.ReadFile(
+		path,
+	)
+	if readError != nil {
+		return nil, readError
+	}
+	var content = &leaseContent{}
+	var unmarshalError = json.Unmarshal(
+		data,
+		content,
+	)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf(
+			"Invalid lease file [%v]: %w",
+			path,
+			unmarshalError,
+		)
+	}
+	return content, nil
+}
+
+// writeLeaseFile writes the lease content to a temporary file first, and then commits it to the lease path at once, i.e. through os.Link for creating it only if not existing, or through os.Rename for replacing it
+func writeLeaseFile(path string, content *leaseContent, commit func(oldPath string, newPath string) error) error {
+	var data, marshalError = json.Marshal(
+		content,
+	)
+	if marshalError != nil {
+		return marshalError
+	}
+	var tempPath = fmt.Sprintf(
+		"%v.%v",
+		path,
+		uuid.New(),
+	)
+	defer os.Remove(tempPath)
+	var writeError = os.WriteFile(
+		tempPath,
+		data,
+		0644,
+	)
+	if writeError != nil {
+		return writeError
+	}
+	return commit(
+		tempPath,
+		path,
+	)
+}
+
+// createLeaseFile creates the lease file with the given content, and returns false if the lease file already exists
+func createLeaseFile(path string, content *leaseContent) (bool, error) {
+	var linkError = writeLeaseFile(
+		path,
+		content,
+		os.Link,
+	)
+	if linkError != nil {
+		if os.IsExist(linkError) {
+			return false, nil
+		}
+		return false, linkError
+	}
+	return true, nil
+}
+
+// removeExpiredLeaseFile moves the expired lease file aside and removes it unless renewed meanwhile, and returns false if not removed
+func removeExpiredLeaseFile(path string) (bool, error) {
+	var current, readError = readLeaseFile(
+		path,
+	)
+	if readError != nil {
+		if os.IsNotExist(readError) {
+			return true, nil
+		}
+		return false, readError
+	}
+	if time.Now().Before(current.ExpiresAt) {
+		return false, nil
+	}
+	var stalePath = fmt.Sprintf(
+		"%v.%v",
+		path,
+		uuid.New(),
+	)
+	var renameError = os.Rename(
+		path,
+		stalePath,
+	)
+	if renameError != nil {
+		if os.IsNotExist(renameError) {
+			return false, nil
+		}
+		return false, renameError
+	}
+	defer os.Remove(stalePath)
+	var stale, staleError = readLeaseFile(
+		stalePath,
+	)
+	if staleError != nil {
+		return false, staleError
+	}
+	if stale.Owner != current.Owner ||
+		!stale.ExpiresAt.Equal(current.ExpiresAt) {
+		var linkError = os.Link(
+			stalePath,
+			path,
+		)
+		if linkError != nil &&
+			!os.IsExist(linkError) {
+			return false, linkError
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// takeOverLeaseFile replaces the existing lease file with the given content if it has expired
+func takeOverLeaseFile(path string, content *leaseContent) (bool, error) {
+	var removed, removeError = removeExpiredLeaseFile(
+		path,
+	)
+	if removeError != nil ||
+		!removed {
+		return false, removeError
+	}
+	return createLeaseFile(
+		path,
+		content,
+	)
+}
+
+// sweepLeaseFiles removes the expired lease files in the directory at most once per TTL, e.g. those of past slots left behind by exited processes
+func sweepLeaseFiles(locker *fileLocker, ttl time.Duration) {
+	locker.lock.Lock()
+	if time.Since(locker.sweptAt) < ttl {
+		locker.lock.Unlock()
+		return
+	}
+	locker.sweptAt = time.Now()
+	locker.lock.Unlock()
+	var entries, _ = os.ReadDir(
+		locker.directory,
+	)
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".lease" {
+			continue
+		}
+		removeExpiredLeaseFile(
+			filepath.Join(
+				locker.directory,
+				entry.Name(),
+			),
+		)
+	}
+}
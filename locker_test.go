@@ -0,0 +1,745 @@
+package jobrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func writeDummyLeaseFile(t *testing.T, path string, owner string, expiresAt time.Time) {
+	var data, _ = json.Marshal(&leaseContent{Owner: owner, ExpiresAt: expiresAt})
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestNewFileLocker(t *testing.T) {
+	// arrange
+	var dummyDirectory = "some directory"
+
+	// SUT + act
+	var result = NewFileLocker(
+		dummyDirectory,
+	)
+
+	// assert
+	var locker, ok = result.(*fileLocker)
+	assert.True(t, ok)
+	assert.Equal(t, dummyDirectory, locker.directory)
+	assert.NotEmpty(t, locker.owner)
+}
+
+func TestFileLocker_Acquire_CreateError(t *testing.T) {
+	// arrange
+	var dummyLocker = NewFileLocker(filepath.Join(t.TempDir(), "some folder"))
+
+	// SUT + act
+	var lease, err = dummyLocker.Acquire(
+		"some key",
+		time.Minute,
+	)
+
+	// assert
+	assert.Nil(t, lease)
+	assert.Error(t, err)
+}
+
+func TestFileLocker_Acquire_Created(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyLocker = NewFileLocker(dummyDirectory)
+
+	// SUT + act
+	var lease, err = dummyLocker.Acquire(
+		"some key/1",
+		time.Minute,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var fileLease, ok = lease.(*fileLease)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(dummyDirectory, "some%20key%2F1.lease"), fileLease.path)
+	var content, readError = readLeaseFile(fileLease.path)
+	assert.NoError(t, readError)
+	assert.Equal(t, fileLease.owner, content.Owner)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), content.ExpiresAt, time.Second)
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileLocker_Acquire_Held(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyLocker1 = NewFileLocker(dummyDirectory)
+	var dummyLocker2 = NewFileLocker(dummyDirectory)
+	var dummyLease, _ = dummyLocker1.Acquire("some key", time.Minute)
+
+	// SUT + act
+	var lease, err = dummyLocker2.Acquire(
+		"some key",
+		time.Minute,
+	)
+
+	// assert
+	assert.NotNil(t, dummyLease)
+	assert.Nil(t, lease)
+	assert.NoError(t, err)
+}
+
+func TestFileLocker_Acquire_TakeOverError(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some-key.lease")
+	var dummyLocker = NewFileLocker(dummyDirectory)
+	var dummyError = errors.New("some error")
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "some owner", time.Now().Add(time.Minute))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(takeOverLeaseFile).Expects(dummyPath, gomocker.Anything()).Returns(false, dummyError).Once()
+
+	// SUT + act
+	var lease, err = dummyLocker.Acquire(
+		"some-key",
+		time.Minute,
+	)
+
+	// assert
+	assert.Nil(t, lease)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestFileLocker_Acquire_TakenOver(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some-key.lease")
+	var dummyLocker = NewFileLocker(dummyDirectory)
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "some owner", time.Now().Add(-time.Second))
+
+	// SUT + act
+	var lease, err = dummyLocker.Acquire(
+		"some-key",
+		time.Minute,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyPath)
+	assert.Equal(t, lease.(*fileLease).owner, content.Owner)
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileLease_Renew_Lost(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// SUT + act
+	var err = dummyLease.Renew(
+		time.Minute,
+	)
+
+	// assert
+	assert.Equal(t, ErrLeaseLost, err)
+}
+
+func TestFileLease_Renew_ReadError(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// stub
+	os.WriteFile(dummyLease.path, []byte("some content"), 0644)
+
+	// SUT + act
+	var err = dummyLease.Renew(
+		time.Minute,
+	)
+
+	// assert
+	assert.ErrorContains(t, err, "Invalid lease file")
+}
+
+func TestFileLease_Renew_TakenOver(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// stub
+	writeDummyLeaseFile(t, dummyLease.path, "other owner", time.Now().Add(time.Minute))
+
+	// SUT + act
+	var err = dummyLease.Renew(
+		time.Minute,
+	)
+
+	// assert
+	assert.Equal(t, ErrLeaseLost, err)
+}
+
+func TestFileLease_Renew_Renewed(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// stub
+	writeDummyLeaseFile(t, dummyLease.path, "some owner", time.Now().Add(time.Second))
+
+	// SUT + act
+	var err = dummyLease.Renew(
+		time.Hour,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyLease.path)
+	assert.Equal(t, "some owner", content.Owner)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), content.ExpiresAt, time.Second)
+}
+
+func TestFileLease_Release_NotExist(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// SUT + act
+	var err = dummyLease.Release()
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestFileLease_Release_ReadError(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// stub
+	os.WriteFile(dummyLease.path, []byte("some content"), 0644)
+
+	// SUT + act
+	var err = dummyLease.Release()
+
+	// assert
+	assert.ErrorContains(t, err, "Invalid lease file")
+}
+
+func TestFileLease_Release_TakenOver(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// stub
+	writeDummyLeaseFile(t, dummyLease.path, "other owner", time.Now().Add(time.Minute))
+
+	// SUT + act
+	var err = dummyLease.Release()
+
+	// assert
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyLease.path)
+	assert.Equal(t, "other owner", content.Owner)
+}
+
+func TestFileLease_Release_RemoveError(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+	var dummyError = errors.New("some error")
+
+	// stub
+	writeDummyLeaseFile(t, dummyLease.path, "some owner", time.Now().Add(time.Minute))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Remove).Expects(dummyLease.path).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = dummyLease.Release()
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestFileLease_Release_Released(t *testing.T) {
+	// arrange
+	var dummyLease = &fileLease{
+		path:  filepath.Join(t.TempDir(), "some key.lease"),
+		owner: "some owner",
+	}
+
+	// stub
+	writeDummyLeaseFile(t, dummyLease.path, "some owner", time.Now().Add(time.Minute))
+
+	// SUT + act
+	var err = dummyLease.Release()
+
+	// assert
+	assert.NoError(t, err)
+	var _, statError = os.Stat(dummyLease.path)
+	assert.True(t, os.IsNotExist(statError))
+}
+
+func TestWriteLeaseFile_MarshalError(t *testing.T) {
+	// arrange
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(json.Marshal).Expects(dummyContent).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var err = writeLeaseFile(
+		"some path",
+		dummyContent,
+		os.Rename,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteLeaseFile_WriteError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some folder", "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var commitCalled = 0
+	var dummyCommit = func(oldPath string, newPath string) error {
+		commitCalled++
+		return nil
+	}
+
+	// SUT + act
+	var err = writeLeaseFile(
+		dummyPath,
+		dummyContent,
+		dummyCommit,
+	)
+
+	// assert
+	assert.Error(t, err)
+	assert.Zero(t, commitCalled)
+}
+
+func TestWriteLeaseFile_Committed(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner", ExpiresAt: time.Now().UTC()}
+	var dummyError = errors.New("some error")
+	var dummyCommit = func(oldPath string, newPath string) error {
+		assert.True(t, strings.HasPrefix(oldPath, dummyPath+"."))
+		assert.Equal(t, dummyPath, newPath)
+		var content, readError = readLeaseFile(oldPath)
+		assert.NoError(t, readError)
+		assert.Equal(t, dummyContent.Owner, content.Owner)
+		assert.True(t, dummyContent.ExpiresAt.Equal(content.ExpiresAt))
+		return dummyError
+	}
+
+	// SUT + act
+	var err = writeLeaseFile(
+		dummyPath,
+		dummyContent,
+		dummyCommit,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Empty(t, entries)
+}
+
+func TestCreateLeaseFile_Error(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(writeLeaseFile).Expects(dummyPath, dummyContent, gomocker.Anything()).Returns(dummyError).Once()
+
+	// SUT + act
+	var created, err = createLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, created)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestCreateLeaseFile_Exists(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "other owner", time.Now())
+
+	// SUT + act
+	var created, err = createLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, created)
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyPath)
+	assert.Equal(t, "other owner", content.Owner)
+}
+
+func TestCreateLeaseFile_Created(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// SUT + act
+	var created, err = createLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.True(t, created)
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyPath)
+	assert.Equal(t, "some owner", content.Owner)
+}
+
+func TestTakeOverLeaseFile_Released(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.True(t, takenOver)
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyPath)
+	assert.Equal(t, "some owner", content.Owner)
+}
+
+func TestTakeOverLeaseFile_ReadError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// stub
+	os.WriteFile(dummyPath, []byte("some content"), 0644)
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.ErrorContains(t, err, "Invalid lease file")
+}
+
+func TestTakeOverLeaseFile_NotExpired(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "other owner", time.Now().Add(time.Minute))
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyPath)
+	assert.Equal(t, "other owner", content.Owner)
+}
+
+func TestTakeOverLeaseFile_RenameNotExist(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "other owner", time.Now().Add(-time.Second))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Rename).Expects(dummyPath, gomocker.Anything()).Returns(
+		&os.LinkError{Op: "rename", Err: fs.ErrNotExist}).Once()
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.NoError(t, err)
+}
+
+func TestTakeOverLeaseFile_RenameError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var dummyError = errors.New("some error")
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "other owner", time.Now().Add(-time.Second))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.Rename).Expects(dummyPath, gomocker.Anything()).Returns(dummyError).Once()
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestTakeOverLeaseFile_StaleReadError(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var dummyCurrent = &leaseContent{Owner: "other owner", ExpiresAt: time.Now().Add(-time.Second)}
+	var dummyError = errors.New("some error")
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, dummyCurrent.Owner, dummyCurrent.ExpiresAt)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(readLeaseFile).Expects(dummyPath).Returns(dummyCurrent, nil).Once()
+	m.Mock(readLeaseFile).Expects(gomocker.Anything()).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.Equal(t, dummyError, err)
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Empty(t, entries)
+}
+
+func TestTakeOverLeaseFile_Changed(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var dummyCurrent = &leaseContent{Owner: "other owner", ExpiresAt: time.Now().Add(-time.Second)}
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "third owner", time.Now().Add(time.Minute))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(readLeaseFile).Expects(dummyPath).Returns(dummyCurrent, nil).Once()
+	m.Mock(readLeaseFile).Expects(gomocker.Anything()).Returns(&leaseContent{Owner: "third owner"}, nil).Once()
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.NoError(t, err)
+	var data, _ = os.ReadFile(dummyPath)
+	assert.Contains(t, string(data), "third owner")
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+}
+
+func TestTakeOverLeaseFile_LinkError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+	var dummyCurrent = &leaseContent{Owner: "other owner", ExpiresAt: time.Now().Add(-time.Second)}
+	var dummyError = errors.New("some error")
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "third owner", time.Now().Add(time.Minute))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(readLeaseFile).Expects(dummyPath).Returns(dummyCurrent, nil).Once()
+	m.Mock(readLeaseFile).Expects(gomocker.Anything()).Returns(&leaseContent{Owner: "third owner"}, nil).Once()
+	m.Mock(os.Link).Expects(gomocker.Anything(), dummyPath).Returns(dummyError).Once()
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.False(t, takenOver)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestTakeOverLeaseFile_TakenOver(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some key.lease")
+	var dummyContent = &leaseContent{Owner: "some owner"}
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "other owner", time.Now().Add(-time.Second))
+
+	// SUT + act
+	var takenOver, err = takeOverLeaseFile(
+		dummyPath,
+		dummyContent,
+	)
+
+	// assert
+	assert.True(t, takenOver)
+	assert.NoError(t, err)
+	var content, _ = readLeaseFile(dummyPath)
+	assert.Equal(t, "some owner", content.Owner)
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+}
+
+func TestSweepLeaseFiles_Throttled(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyPath = filepath.Join(dummyDirectory, "some key.lease")
+	var dummyLocker = &fileLocker{directory: dummyDirectory, sweptAt: time.Now()}
+
+	// stub
+	writeDummyLeaseFile(t, dummyPath, "other owner", time.Now().Add(-time.Second))
+
+	// SUT + act
+	sweepLeaseFiles(
+		dummyLocker,
+		time.Minute,
+	)
+
+	// assert
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+}
+
+func TestSweepLeaseFiles_Swept(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyExpiredPath = filepath.Join(dummyDirectory, "some key.lease")
+	var dummyHeldPath = filepath.Join(dummyDirectory, "other key.lease")
+	var dummyOtherPath = filepath.Join(dummyDirectory, "some file")
+	var dummyLocker = &fileLocker{directory: dummyDirectory, sweptAt: time.Now().Add(-time.Minute)}
+
+	// stub
+	writeDummyLeaseFile(t, dummyExpiredPath, "some owner", time.Now().Add(-time.Second))
+	writeDummyLeaseFile(t, dummyHeldPath, "other owner", time.Now().Add(time.Minute))
+	writeDummyLeaseFile(t, dummyOtherPath, "third owner", time.Now().Add(-time.Second))
+
+	// SUT + act
+	sweepLeaseFiles(
+		dummyLocker,
+		time.Minute,
+	)
+
+	// assert
+	var entries, _ = os.ReadDir(dummyDirectory)
+	var names = []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"other key.lease", "some file"}, names)
+	assert.WithinDuration(t, time.Now(), dummyLocker.sweptAt, time.Second)
+}
+
+func TestFileLocker_Acquire_Swept(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyLocker = NewFileLocker(dummyDirectory)
+
+	// stub
+	writeDummyLeaseFile(t, filepath.Join(dummyDirectory, "past-key.lease"), "some owner", time.Now().Add(-time.Second))
+
+	// SUT + act
+	var lease, err = dummyLocker.Acquire(
+		"some-key",
+		time.Minute,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NotNil(t, lease)
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "some-key.lease", entries[0].Name())
+}
@@ -0,0 +1,342 @@
+package jobrunner
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// These are the types of metric families in the Prometheus text exposition format
+const (
+	metricTypeCounter   string = "counter"
+	metricTypeGauge     string = "gauge"
+	metricTypeHistogram string = "histogram"
+)
+
+var (
+	instanceDurationBuckets = []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600}
+	webcallDurationBuckets  = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	labelValueEscaper       = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper             = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+// metricSeries is a single series of a metric family, identified by its label values
+type metricSeries struct {
+	values  []string
+	value   float64
+	collect func() float64
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// metricFamily is a named metric with its type, label names and series
+type metricFamily struct {
+	registry *metricsRegistry
+	name     string
+	help     string
+	kind     string
+	labels   []string
+	buckets  []float64
+	series   map[string]*metricSeries
+}
+
+// metricsRegistry holds the metric families to be exposed, in the order of registration
+type metricsRegistry struct {
+	lock     sync.Mutex
+	families []*metricFamily
+}
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		families: []*metricFamily{},
+	}
+}
+
+// register adds a metric family to the registry; buckets are the upper bounds of the histogram buckets in increasing order, ignored for other types
+func (registry *metricsRegistry) register(name string, help string, kind string, buckets []float64, labels ...string) *metricFamily {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	var family = &metricFamily{
+		registry: registry,
+		name:     name,
+		help:     help,
+		kind:     kind,
+		labels:   labels,
+		buckets:  buckets,
+		series:   map[string]*metricSeries{},
+	}
+	registry.families = append(
+		registry.families,
+		family,
+	)
+	return family
+}
+
+// lookup returns the series with the given label values, creating it if not found; the registry lock must be held by the caller
+func (family *metricFamily) lookup(values []string) *metricSeries {
+	var key = strings.Join(values, "\xff")
+	var series, found = family.series[key]
+	if !found {
+		series = &metricSeries{
+			values:  values,
+			buckets: make([]uint64, len(family.buckets)),
+		}
+		family.series[key] = series
+	}
+	return series
+}
+
+// add adds the delta to the series with the given label values of a counter or gauge
+func (family *metricFamily) add(delta float64, values ...string) {
+	family.registry.lock.Lock()
+	defer family.registry.lock.Unlock()
+	family.lookup(values).value += delta
+}
+
+// setFunc sets the series with the given label values of a gauge to be collected from the given function upon each exposition
+func (family *metricFamily) setFunc(collect func() float64, values ...string) {
+	family.registry.lock.Lock()
+	defer family.registry.lock.Unlock()
+	family.lookup(values).collect = collect
+}
+
+// remove removes the series with the given label values, which is no longer exposed
+func (family *metricFamily) remove(values ...string) {
+	family.registry.lock.Lock()
+	defer family.registry.lock.Unlock()
+	delete(family.series, strings.Join(values, "\xff"))
+}
+
+// observe adds the observed value to the series with the given label values of a histogram
+func (family *metricFamily) observe(value float64, values ...string) {
+	family.registry.lock.Lock()
+	defer family.registry.lock.Unlock()
+	var series = family.lookup(values)
+	for index, bound := range family.buckets {
+		if value <= bound {
+			series.buckets[index]++
+		}
+	}
+	series.sum += value
+	series.count++
+}
+
+func formatMetricValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	if math.IsInf(value, -1) {
+		return "-Inf"
+	}
+	if math.IsNaN(value) {
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// formatMetricLabels returns the label pairs of the given names and values, along with the extra pairs given as name followed by value, e.g. the upper bound of a histogram bucket
+func formatMetricLabels(names []string, values []string, extras ...string) string {
+	var pairs = []string{}
+	for index, name := range names {
+		pairs = append(
+			pairs,
+			name+`="`+labelValueEscaper.Replace(values[index])+`"`,
+		)
+	}
+	for index := 0; index+1 < len(extras); index += 2 {
+		pairs = append(
+			pairs,
+			extras[index]+`="`+labelValueEscaper.Replace(extras[index+1])+`"`,
+		)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// writeMetricFamily writes the family with all its series in the order of their label values; families without any series are omitted
+func writeMetricFamily(buffer *bytes.Buffer, family *metricFamily) {
+	if len(family.series) == 0 {
+		return
+	}
+	buffer.WriteString("# HELP " + family.name + " " + helpEscaper.Replace(family.help) + "\n")
+	buffer.WriteString("# TYPE " + family.name + " " + family.kind + "\n")
+	var keys = []string{}
+	for key := range family.series {
+		keys = append(
+			keys,
+			key,
+		)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		var series = family.series[key]
+		if family.kind != metricTypeHistogram {
+			var value = series.value
+			if series.collect != nil {
+				value = series.collect()
+			}
+			buffer.WriteString(family.name + formatMetricLabels(family.labels, series.values) + " " + formatMetricValue(value) + "\n")
+			continue
+		}
+		for index, bound := range family.buckets {
+			buffer.WriteString(family.name + "_bucket" + formatMetricLabels(family.labels, series.values, "le", formatMetricValue(bound)) + " " + strconv.FormatUint(series.buckets[index], 10) + "\n")
+		}
+		buffer.WriteString(family.name + "_bucket" + formatMetricLabels(family.labels, series.values, "le", "+Inf") + " " + strconv.FormatUint(series.count, 10) + "\n")
+		buffer.WriteString(family.name + "_sum" + formatMetricLabels(family.labels, series.values) + " " + formatMetricValue(series.sum) + "\n")
+		buffer.WriteString(family.name + "_count" + formatMetricLabels(family.labels, series.values) + " " + strconv.FormatUint(series.count, 10) + "\n")
+	}
+}
+
+// write writes all metric families of the registry in the Prometheus text exposition format
+func (registry *metricsRegistry) write(writer io.Writer) error {
+	var buffer = &bytes.Buffer{}
+	registry.lock.Lock()
+	for _, family := range registry.families {
+		writeMetricFamily(
+			buffer,
+			family,
+		)
+	}
+	registry.lock.Unlock()
+	var _, writeError = writer.Write(
+		buffer.Bytes(),
+	)
+	return writeError
+}
+
+var (
+	metricsDefault = newMetricsRegistry()
+
+	roundsTotal = metricsDefault.register(
+		"jobrunner_rounds_total",
+		"Total number of recorded rounds by status and whether any of their instances failed",
+		metricTypeCounter,
+		nil,
+		"job", "status", "failed",
+	)
+	instancesTotal = metricsDefault.register(
+		"jobrunner_instances_total",
+		"Total number of executed instances by outcome",
+		metricTypeCounter,
+		nil,
+		"job", "outcome",
+	)
+	instanceDurationSeconds = metricsDefault.register(
+		"jobrunner_instance_duration_seconds",
+		"Duration of executed instances in seconds, including their retries",
+		metricTypeHistogram,
+		instanceDurationBuckets,
+		"job",
+	)
+	panicsTotal = metricsDefault.register(
+		"jobrunner_panics_total",
+		"Total number of panics recovered, e.g. from instances, work items or partitioning",
+		metricTypeCounter,
+		nil,
+		"job",
+	)
+	inflightInstances = metricsDefault.register(
+		"jobrunner_inflight_instances",
+		"Number of instances currently running",
+		metricTypeGauge,
+		nil,
+		"job",
+	)
+	nextRunSeconds = metricsDefault.register(
+		"jobrunner_next_run_seconds",
+		"Seconds until the next scheduled run, or 0 if already due",
+		metricTypeGauge,
+		nil,
+		"job",
+	)
+	webcallDurationSeconds = metricsDefault.register(
+		"jobrunner_webcall_duration_seconds",
+		"Latency of webcalls in seconds including their retries, by host and HTTP status, or error if no response was received",
+		metricTypeHistogram,
+		webcallDurationBuckets,
+		"host", "status",
+	)
+)
+
+// MetricsHandler returns the HTTP handler exposing the metrics of all applications and runners in the process in the Prometheus text exposition format, e.g. for mounting at /metrics of an existing HTTP server
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(
+		serveMetrics,
+	)
+}
+
+func serveMetrics(responseWriter http.ResponseWriter, request *http.Request) {
+	responseWriter.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metricsDefault.write(
+		responseWriter,
+	)
+}
+
+func observeRound(app *application, record *RoundRecord) {
+	roundsTotal.add(
+		1,
+		app.name,
+		record.Status.String(),
+		strconv.FormatBool(record.Failed),
+	)
+}
+
+func observeInstance(app *application, record *InstanceRecord) {
+	instancesTotal.add(
+		1,
+		app.name,
+		record.Outcome.String(),
+	)
+	instanceDurationSeconds.observe(
+		record.Duration.Seconds(),
+		app.name,
+	)
+}
+
+func observeRunError(app *application, runError *RunError) {
+	if runError.Phase != RunPhasePanic {
+		return
+	}
+	panicsTotal.add(
+		1,
+		app.name,
+	)
+}
+
+// observeNextRun exposes the seconds until the given next scheduled time, or stops exposing it if there is no next scheduled time
+func observeNextRun(app *application, timeNext *time.Time) {
+	if timeNext == nil {
+		nextRunSeconds.remove(
+			app.name,
+		)
+		return
+	}
+	var next = *timeNext
+	nextRunSeconds.setFunc(
+		func() float64 {
+			return max(time.Until(next).Seconds(), 0)
+		},
+		app.name,
+	)
+}
+
+func observeWebcall(request *http.Request, response *http.Response, responseError error, startTime time.Time) {
+	var status = "error"
+	if responseError == nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+	webcallDurationSeconds.observe(
+		time.Since(startTime).Seconds(),
+		request.URL.Host,
+		status,
+	)
+}
@@ -0,0 +1,364 @@
+package jobrunner
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func getMetricSeries(family *metricFamily, values ...string) *metricSeries {
+	family.registry.lock.Lock()
+	defer family.registry.lock.Unlock()
+	return family.series[strings.Join(values, "\xff")]
+}
+
+func TestMetricsRegistryRegister(t *testing.T) {
+	// arrange
+	var dummyRegistry = newMetricsRegistry()
+	var dummyBuckets = []float64{1, 2}
+
+	// SUT + act
+	var result = dummyRegistry.register(
+		"some_metric",
+		"some help",
+		metricTypeHistogram,
+		dummyBuckets,
+		"some label", "other label",
+	)
+
+	// assert
+	assert.Equal(t, []*metricFamily{result}, dummyRegistry.families)
+	assert.Equal(t, dummyRegistry, result.registry)
+	assert.Equal(t, "some_metric", result.name)
+	assert.Equal(t, "some help", result.help)
+	assert.Equal(t, metricTypeHistogram, result.kind)
+	assert.Equal(t, []string{"some label", "other label"}, result.labels)
+	assert.Equal(t, dummyBuckets, result.buckets)
+	assert.Empty(t, result.series)
+}
+
+func TestMetricFamilyAdd(t *testing.T) {
+	// arrange
+	var dummyFamily = newMetricsRegistry().register("some_metric", "some help", metricTypeCounter, nil, "job")
+
+	// SUT + act
+	dummyFamily.add(1, "some job")
+	dummyFamily.add(2.5, "some job")
+	dummyFamily.add(1, "other job")
+
+	// assert
+	assert.Equal(t, 3.5, getMetricSeries(dummyFamily, "some job").value)
+	assert.Equal(t, 1.0, getMetricSeries(dummyFamily, "other job").value)
+}
+
+func TestMetricFamilySetFunc(t *testing.T) {
+	// arrange
+	var dummyFamily = newMetricsRegistry().register("some_metric", "some help", metricTypeGauge, nil, "job")
+
+	// SUT + act
+	dummyFamily.setFunc(
+		func() float64 {
+			return 12.5
+		},
+		"some job",
+	)
+
+	// assert
+	assert.Equal(t, 12.5, getMetricSeries(dummyFamily, "some job").collect())
+}
+
+func TestMetricFamilyRemove(t *testing.T) {
+	// arrange
+	var dummyFamily = newMetricsRegistry().register("some_metric", "some help", metricTypeGauge, nil, "job")
+	dummyFamily.add(1, "some job")
+	dummyFamily.add(1, "other job")
+
+	// SUT + act
+	dummyFamily.remove("some job")
+
+	// assert
+	assert.Nil(t, getMetricSeries(dummyFamily, "some job"))
+	assert.NotNil(t, getMetricSeries(dummyFamily, "other job"))
+}
+
+func TestMetricFamilyObserve(t *testing.T) {
+	// arrange
+	var dummyFamily = newMetricsRegistry().register("some_metric", "some help", metricTypeHistogram, []float64{1, 5, 10}, "job")
+
+	// SUT + act
+	dummyFamily.observe(0.5, "some job")
+	dummyFamily.observe(5, "some job")
+	dummyFamily.observe(20, "some job")
+
+	// assert
+	var series = getMetricSeries(dummyFamily, "some job")
+	assert.Equal(t, []uint64{1, 2, 2}, series.buckets)
+	assert.Equal(t, 25.5, series.sum)
+	assert.Equal(t, uint64(3), series.count)
+}
+
+func TestFormatMetricValue(t *testing.T) {
+	// arrange
+	var dummyValues = map[float64]string{
+		math.Inf(1):  "+Inf",
+		math.Inf(-1): "-Inf",
+		0.25:         "0.25",
+		3:            "3",
+		-1e21:        "-1e+21",
+	}
+
+	for value, expected := range dummyValues {
+		// SUT + act
+		var result = formatMetricValue(value)
+
+		// assert
+		assert.Equal(t, expected, result)
+	}
+	assert.Equal(t, "NaN", formatMetricValue(math.NaN()))
+}
+
+func TestFormatMetricLabels_Empty(t *testing.T) {
+	// SUT + act
+	var result = formatMetricLabels(
+		nil,
+		nil,
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestFormatMetricLabels_Escaped(t *testing.T) {
+	// SUT + act
+	var result = formatMetricLabels(
+		[]string{"job", "status"},
+		[]string{"some \"job\"", "some\\status\n"},
+		"le", "0.5",
+	)
+
+	// assert
+	assert.Equal(t, `{job="some \"job\"",status="some\\status\n",le="0.5"}`, result)
+}
+
+func TestMetricsRegistryWrite_Families(t *testing.T) {
+	// arrange
+	var dummyRegistry = newMetricsRegistry()
+	var dummyCounter = dummyRegistry.register("some_total", "some help\nwith \\ escaped", metricTypeCounter, nil, "job")
+	dummyRegistry.register("empty_total", "empty help", metricTypeCounter, nil, "job")
+	var dummyGauge = dummyRegistry.register("some_gauge", "gauge help", metricTypeGauge, nil)
+	var dummyHistogram = dummyRegistry.register("some_seconds", "histogram help", metricTypeHistogram, []float64{0.5, 1}, "job")
+	dummyCounter.add(2, "some job")
+	dummyCounter.add(1, "other job")
+	dummyGauge.setFunc(
+		func() float64 {
+			return 7
+		},
+	)
+	dummyHistogram.observe(0.25, "some job")
+	dummyHistogram.observe(2, "some job")
+	var dummyBuilder = &strings.Builder{}
+
+	// SUT + act
+	var err = dummyRegistry.write(
+		dummyBuilder,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP some_total some help\nwith \\ escaped
+# TYPE some_total counter
+some_total{job="other job"} 1
+some_total{job="some job"} 2
+# HELP some_gauge gauge help
+# TYPE some_gauge gauge
+some_gauge 7
+# HELP some_seconds histogram help
+# TYPE some_seconds histogram
+some_seconds_bucket{job="some job",le="0.5"} 1
+some_seconds_bucket{job="some job",le="1"} 1
+some_seconds_bucket{job="some job",le="+Inf"} 2
+some_seconds_sum{job="some job"} 2.25
+some_seconds_count{job="some job"} 2
+`, dummyBuilder.String())
+}
+
+type failingMetricsWriter struct{}
+
+func (writer *failingMetricsWriter) Write(data []byte) (int, error) {
+	return 0, errors.New("some error")
+}
+
+func TestMetricsRegistryWrite_Error(t *testing.T) {
+	// arrange
+	var dummyRegistry = newMetricsRegistry()
+
+	// SUT + act
+	var err = dummyRegistry.write(
+		&failingMetricsWriter{},
+	)
+
+	// assert
+	assert.EqualError(t, err, "some error")
+}
+
+func TestMetricsHandler(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	observeRound(dummyApplication, &RoundRecord{Status: RoundStatusCompleted})
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyRequest = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+
+	// SUT
+	var sut = MetricsHandler()
+
+	// act
+	sut.ServeHTTP(
+		dummyRecorder,
+		dummyRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", dummyRecorder.Header().Get("Content-Type"))
+	assert.Contains(t, dummyRecorder.Body.String(), "# TYPE jobrunner_rounds_total counter\n")
+	assert.Contains(t, dummyRecorder.Body.String(), `jobrunner_rounds_total{job="`+dummyApplication.name+`",status="Completed",failed="false"} 1`+"\n")
+}
+
+func TestObserveRound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	var dummyRecord = &RoundRecord{Status: RoundStatusCompleted, Failed: true}
+
+	// SUT + act
+	observeRound(dummyApplication, dummyRecord)
+	observeRound(dummyApplication, dummyRecord)
+
+	// assert
+	assert.Equal(t, 2.0, getMetricSeries(roundsTotal, dummyApplication.name, "Completed", "true").value)
+}
+
+func TestObserveInstance(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	var dummyRecord = &InstanceRecord{Outcome: InstanceOutcomeTimeout, Duration: 2 * time.Second}
+
+	// SUT + act
+	observeInstance(
+		dummyApplication,
+		dummyRecord,
+	)
+
+	// assert
+	assert.Equal(t, 1.0, getMetricSeries(instancesTotal, dummyApplication.name, "Timeout").value)
+	var series = getMetricSeries(instanceDurationSeconds, dummyApplication.name)
+	assert.Equal(t, 2.0, series.sum)
+	assert.Equal(t, uint64(1), series.count)
+}
+
+func TestObserveRunError_NotPanic(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	var dummyRunError = &RunError{Phase: RunPhaseAction}
+
+	// SUT + act
+	observeRunError(
+		dummyApplication,
+		dummyRunError,
+	)
+
+	// assert
+	assert.Nil(t, getMetricSeries(panicsTotal, dummyApplication.name))
+}
+
+func TestObserveRunError_Panic(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	var dummyRunError = &RunError{Phase: RunPhasePanic}
+
+	// SUT + act
+	observeRunError(
+		dummyApplication,
+		dummyRunError,
+	)
+
+	// assert
+	assert.Equal(t, 1.0, getMetricSeries(panicsTotal, dummyApplication.name).value)
+}
+
+func TestObserveNextRun_Scheduled(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	var dummyPast = time.Now().Add(-time.Minute)
+	var dummyFuture = time.Now().Add(time.Hour)
+
+	// SUT + act
+	observeNextRun(dummyApplication, &dummyPast)
+	var pastResult = getMetricSeries(nextRunSeconds, dummyApplication.name).collect()
+	observeNextRun(dummyApplication, &dummyFuture)
+	var futureResult = getMetricSeries(nextRunSeconds, dummyApplication.name).collect()
+
+	// assert
+	assert.Zero(t, pastResult)
+	assert.InDelta(t, time.Hour.Seconds(), futureResult, 1)
+}
+
+func TestObserveNextRun_None(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: uuid.NewString()}
+	var dummyNext = time.Now().Add(time.Hour)
+	observeNextRun(dummyApplication, &dummyNext)
+
+	// SUT + act
+	observeNextRun(
+		dummyApplication,
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, getMetricSeries(nextRunSeconds, dummyApplication.name))
+}
+
+func TestObserveWebcall_Error(t *testing.T) {
+	// arrange
+	var dummyHost = uuid.NewString()
+	var dummyRequest = &http.Request{URL: &url.URL{Host: dummyHost}}
+
+	// SUT + act
+	observeWebcall(
+		dummyRequest,
+		nil,
+		errors.New("some error"),
+		time.Now().Add(-time.Second),
+	)
+
+	// assert
+	var series = getMetricSeries(webcallDurationSeconds, dummyHost, "error")
+	assert.Equal(t, uint64(1), series.count)
+	assert.InDelta(t, 1, series.sum, 0.5)
+}
+
+func TestObserveWebcall_Response(t *testing.T) {
+	// arrange
+	var dummyHost = uuid.NewString()
+	var dummyRequest = &http.Request{URL: &url.URL{Host: dummyHost}}
+	var dummyResponse = &http.Response{StatusCode: http.StatusAccepted}
+
+	// SUT + act
+	observeWebcall(
+		dummyRequest,
+		dummyResponse,
+		nil,
+		time.Now(),
+	)
+
+	// assert
+	assert.Equal(t, uint64(1), getMetricSeries(webcallDurationSeconds, dummyHost, "202").count)
+}
@@ -0,0 +1,44 @@
+package jobrunner
+
+// OverflowPolicy is the policy of handling the rounds which become due while the number of running rounds has reached the cap of concurrent rounds
+type OverflowPolicy int
+
+// These are the enum definitions of overflow policies
+const (
+	OverflowPolicySkip OverflowPolicy = iota
+	OverflowPolicyQueue
+)
+
+// These are the string representations of overflow policies
+const (
+	skipOverflowPolicyName  string = "Skip"
+	queueOverflowPolicyName string = "Queue"
+)
+
+var supportedOverflowPolicies = map[OverflowPolicy]string{
+	OverflowPolicySkip:  skipOverflowPolicyName,
+	OverflowPolicyQueue: queueOverflowPolicyName,
+}
+
+var overflowPolicyNameMapping = map[string]OverflowPolicy{
+	skipOverflowPolicyName:  OverflowPolicySkip,
+	queueOverflowPolicyName: OverflowPolicyQueue,
+}
+
+// String converts a OverflowPolicy instance to its string representation
+func (overflowPolicy OverflowPolicy) String() string {
+	var name, found = supportedOverflowPolicies[overflowPolicy]
+	if !found {
+		return skipOverflowPolicyName
+	}
+	return name
+}
+
+// NewOverflowPolicy converts a string representation of OverflowPolicy to its strongly typed instance
+func NewOverflowPolicy(value string) OverflowPolicy {
+	var overflowPolicy, found = overflowPolicyNameMapping[value]
+	if !found {
+		return OverflowPolicySkip
+	}
+	return overflowPolicy
+}
@@ -0,0 +1,50 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverflowPolicyString_NonSupportedOverflowPolicy(t *testing.T) {
+	// SUT
+	var sut = OverflowPolicy(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, skipOverflowPolicyName, result)
+}
+
+func TestOverflowPolicyString_SupportedOverflowPolicy(t *testing.T) {
+	// SUT
+	var sut = OverflowPolicyQueue
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, queueOverflowPolicyName, result)
+}
+
+func TestNewOverflowPolicy_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewOverflowPolicy(dummyValue)
+
+	// assert
+	assert.Equal(t, OverflowPolicySkip, result)
+}
+
+func TestNewOverflowPolicy_HappyPath(t *testing.T) {
+	for key, value := range overflowPolicyNameMapping {
+		// SUT + act
+		var result = NewOverflowPolicy(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
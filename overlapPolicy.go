@@ -0,0 +1,52 @@
+package jobrunner
+
+// OverlapPolicy is the policy of handling a round becoming due while previous rounds have not yet completed
+type OverlapPolicy int
+
+// These are the enum definitions of overlap policies
+const (
+	OverlapPolicySkip OverlapPolicy = iota
+	OverlapPolicyAllow
+	OverlapPolicyQueueOne
+	OverlapPolicyCancelPrevious
+)
+
+// These are the string representations of overlap policies
+const (
+	skipOverlapPolicyName           string = "Skip"
+	allowOverlapPolicyName          string = "Allow"
+	queueOneOverlapPolicyName       string = "QueueOne"
+	cancelPreviousOverlapPolicyName string = "CancelPrevious"
+)
+
+var supportedOverlapPolicies = map[OverlapPolicy]string{
+	OverlapPolicySkip:           skipOverlapPolicyName,
+	OverlapPolicyAllow:          allowOverlapPolicyName,
+	OverlapPolicyQueueOne:       queueOneOverlapPolicyName,
+	OverlapPolicyCancelPrevious: cancelPreviousOverlapPolicyName,
+}
+
+var overlapPolicyNameMapping = map[string]OverlapPolicy{
+	skipOverlapPolicyName:           OverlapPolicySkip,
+	allowOverlapPolicyName:          OverlapPolicyAllow,
+	queueOneOverlapPolicyName:       OverlapPolicyQueueOne,
+	cancelPreviousOverlapPolicyName: OverlapPolicyCancelPrevious,
+}
+
+// String converts an OverlapPolicy instance to its string representation
+func (overlapPolicy OverlapPolicy) String() string {
+	var name, found = supportedOverlapPolicies[overlapPolicy]
+	if !found {
+		return skipOverlapPolicyName
+	}
+	return name
+}
+
+// NewOverlapPolicy converts a string representation of OverlapPolicy to its strongly typed instance
+func NewOverlapPolicy(value string) OverlapPolicy {
+	var overlapPolicy, found = overlapPolicyNameMapping[value]
+	if !found {
+		return OverlapPolicySkip
+	}
+	return overlapPolicy
+}
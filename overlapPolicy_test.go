@@ -0,0 +1,50 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlapPolicyString_NonSupportedOverlapPolicy(t *testing.T) {
+	// SUT
+	var sut = OverlapPolicy(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, skipOverlapPolicyName, result)
+}
+
+func TestOverlapPolicyString_SupportedOverlapPolicy(t *testing.T) {
+	// SUT
+	var sut = OverlapPolicyCancelPrevious
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, cancelPreviousOverlapPolicyName, result)
+}
+
+func TestNewOverlapPolicy_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewOverlapPolicy(dummyValue)
+
+	// assert
+	assert.Equal(t, OverlapPolicySkip, result)
+}
+
+func TestNewOverlapPolicy_HappyPath(t *testing.T) {
+	for key, value := range overlapPolicyNameMapping {
+		// SUT + act
+		var result = NewOverlapPolicy(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
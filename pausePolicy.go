@@ -0,0 +1,44 @@
+package jobrunner
+
+// PausePolicy is the policy of handling the scheduled rounds which become due while the application is paused
+type PausePolicy int
+
+// These are the enum definitions of pause policies
+const (
+	PausePolicySkip PausePolicy = iota
+	PausePolicyQueue
+)
+
+// These are the string representations of pause policies
+const (
+	skipPausePolicyName  string = "Skip"
+	queuePausePolicyName string = "Queue"
+)
+
+var supportedPausePolicies = map[PausePolicy]string{
+	PausePolicySkip:  skipPausePolicyName,
+	PausePolicyQueue: queuePausePolicyName,
+}
+
+var pausePolicyNameMapping = map[string]PausePolicy{
+	skipPausePolicyName:  PausePolicySkip,
+	queuePausePolicyName: PausePolicyQueue,
+}
+
+// String converts a PausePolicy instance to its string representation
+func (pausePolicy PausePolicy) String() string {
+	var name, found = supportedPausePolicies[pausePolicy]
+	if !found {
+		return skipPausePolicyName
+	}
+	return name
+}
+
+// NewPausePolicy converts a string representation of PausePolicy to its strongly typed instance
+func NewPausePolicy(value string) PausePolicy {
+	var pausePolicy, found = pausePolicyNameMapping[value]
+	if !found {
+		return PausePolicySkip
+	}
+	return pausePolicy
+}
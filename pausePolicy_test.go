@@ -0,0 +1,50 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPausePolicyString_NonSupportedPausePolicy(t *testing.T) {
+	// SUT
+	var sut = PausePolicy(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, skipPausePolicyName, result)
+}
+
+func TestPausePolicyString_SupportedPausePolicy(t *testing.T) {
+	// SUT
+	var sut = PausePolicyQueue
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, queuePausePolicyName, result)
+}
+
+func TestNewPausePolicy_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewPausePolicy(dummyValue)
+
+	// assert
+	assert.Equal(t, PausePolicySkip, result)
+}
+
+func TestNewPausePolicy_HappyPath(t *testing.T) {
+	for key, value := range pausePolicyNameMapping {
+		// SUT + act
+		var result = NewPausePolicy(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
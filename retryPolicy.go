@@ -0,0 +1,95 @@
+package jobrunner
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy is the policy of retrying the failed attempts of an instance within the same round, instead of waiting for the next round
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one; 0 or 1 disables retrying
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled for every further retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay before each retry; 0 or negative means no cap
+	MaxBackoff time.Duration
+	// Jitter is the ratio in [0, 1] of the random deviation applied to each delay, e.g. 0.2 for a deviation of up to 20% either way
+	Jitter float64
+	// Retryable decides whether the given error is worth retrying; if nil, all errors are retried
+	Retryable func(err error) bool
+}
+
+// getRetryBackoff calculates the delay before the retry following the given number of failed attempts, with the exponential growth capped and jittered according to the retry policy
+func getRetryBackoff(policy RetryPolicy, attempt int) time.Duration {
+	var backoff = policy.InitialBackoff
+	for retry := 1; retry < attempt; retry++ {
+		if backoff > math.MaxInt64/2 ||
+			(policy.MaxBackoff > 0 && backoff >= policy.MaxBackoff) {
+			break
+		}
+		backoff *= 2
+	}
+	if policy.MaxBackoff > 0 &&
+		backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+	var jitter = math.Min(
+		math.Max(
+			policy.Jitter,
+			0,
+		),
+		1,
+	)
+	return time.Duration(
+		float64(backoff) * (1 + jitter*(2*rand.Float64()-1)),
+	)
+}
+
+// retrySession decides according to the retry policy whether the failed attempt of the session is to be retried, in which case it waits for the backoff and advances the attempt of the session
+func retrySession(app *application, session *session, phase RunPhase, err error) bool {
+	if session.attempt >= app.retry.MaxAttempts {
+		return false
+	}
+	if app.retry.Retryable != nil &&
+		!app.retry.Retryable(err) {
+		logMethodLogic(
+			session,
+			LogLevelInfo,
+			app.name,
+			"retrySession",
+			"Attempt [%v] failed in phase [%v] with non-retryable error: %+v",
+			session.attempt,
+			phase,
+			err,
+		)
+		return false
+	}
+	var backoff = getRetryBackoff(
+		app.retry,
+		session.attempt,
+	)
+	logMethodLogic(
+		session,
+		LogLevelWarn,
+		app.name,
+		"retrySession",
+		"Attempt [%v] of [%v] failed in phase [%v], retrying in [%s]. Error: %+v",
+		session.attempt,
+		app.retry.MaxAttempts,
+		phase,
+		backoff,
+		err,
+	)
+	var timer = time.NewTimer(
+		backoff,
+	)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-session.Context().Done():
+		return false
+	}
+	session.attempt++
+	return true
+}
@@ -0,0 +1,253 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestGetRetryBackoff_FirstRetry(t *testing.T) {
+	// arrange
+	var dummyPolicy = RetryPolicy{
+		InitialBackoff: time.Second,
+	}
+
+	// SUT + act
+	var result = getRetryBackoff(
+		dummyPolicy,
+		1,
+	)
+
+	// assert
+	assert.Equal(t, time.Second, result)
+}
+
+func TestGetRetryBackoff_Exponential(t *testing.T) {
+	// arrange
+	var dummyPolicy = RetryPolicy{
+		InitialBackoff: time.Second,
+	}
+
+	// SUT + act
+	var result = getRetryBackoff(
+		dummyPolicy,
+		4,
+	)
+
+	// assert
+	assert.Equal(t, 8*time.Second, result)
+}
+
+func TestGetRetryBackoff_Capped(t *testing.T) {
+	// arrange
+	var dummyPolicy = RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+
+	// SUT + act
+	var result = getRetryBackoff(
+		dummyPolicy,
+		10,
+	)
+
+	// assert
+	assert.Equal(t, 5*time.Second, result)
+}
+
+func TestGetRetryBackoff_Overflow(t *testing.T) {
+	// arrange
+	var dummyPolicy = RetryPolicy{
+		InitialBackoff: time.Second,
+	}
+
+	// SUT + act
+	var result = getRetryBackoff(
+		dummyPolicy,
+		100,
+	)
+
+	// assert
+	assert.Greater(t, result, time.Duration(math.MaxInt64/4))
+}
+
+func TestGetRetryBackoff_Jitter(t *testing.T) {
+	// arrange
+	var dummyPolicy = RetryPolicy{
+		InitialBackoff: time.Second,
+		Jitter:         2,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(rand.Float64).Expects().Returns(0.75).Once()
+
+	// SUT + act
+	var result = getRetryBackoff(
+		dummyPolicy,
+		1,
+	)
+
+	// assert
+	assert.Equal(t, 1500*time.Millisecond, result)
+}
+
+func TestRetrySession_MaxAttemptsReached(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		retry: RetryPolicy{
+			MaxAttempts: 3,
+		},
+	}
+	var dummySession = &session{
+		id:      uuid.New(),
+		attempt: 3,
+	}
+	var dummyError = errors.New("some error")
+
+	// SUT + act
+	var result = retrySession(
+		dummyApplication,
+		dummySession,
+		RunPhaseAction,
+		dummyError,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, 3, dummySession.attempt)
+}
+
+func TestRetrySession_NotRetryable(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyError = errors.New("some error")
+	var dummyApplication = &application{
+		name: dummyName,
+		retry: RetryPolicy{
+			MaxAttempts: 3,
+			Retryable: func(err error) bool {
+				return err != dummyError
+			},
+		},
+	}
+	var dummySession = &session{
+		id:      uuid.New(),
+		attempt: 1,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logMethodLogic).Expects(dummySession, LogLevelInfo, dummyName, "retrySession",
+		"Attempt [%v] failed in phase [%v] with non-retryable error: %+v",
+		1, RunPhaseAction, dummyError).Returns().Once()
+
+	// SUT + act
+	var result = retrySession(
+		dummyApplication,
+		dummySession,
+		RunPhaseAction,
+		dummyError,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, 1, dummySession.attempt)
+}
+
+func TestRetrySession_Cancelled(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name: dummyName,
+		retry: RetryPolicy{
+			MaxAttempts: 3,
+		},
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummySession = &session{
+		id:      uuid.New(),
+		attempt: 1,
+		ctx:     dummyContext,
+	}
+	var dummyError = errors.New("some error")
+	var dummyBackoff = time.Hour
+
+	// stub
+	dummyCancel()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRetryBackoff).Expects(dummyApplication.retry, 1).Returns(dummyBackoff).Once()
+	m.Mock(logMethodLogic).Expects(dummySession, LogLevelWarn, dummyName, "retrySession",
+		"Attempt [%v] of [%v] failed in phase [%v], retrying in [%s]. Error: %+v",
+		1, 3, RunPhasePreAction, dummyBackoff, dummyError).Returns().Once()
+
+	// SUT + act
+	var result = retrySession(
+		dummyApplication,
+		dummySession,
+		RunPhasePreAction,
+		dummyError,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, 1, dummySession.attempt)
+}
+
+func TestRetrySession_Retried(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name: dummyName,
+		retry: RetryPolicy{
+			MaxAttempts: 3,
+			Retryable: func(err error) bool {
+				return true
+			},
+		},
+	}
+	var dummySession = &session{
+		id:      uuid.New(),
+		attempt: 2,
+		ctx:     context.Background(),
+	}
+	var dummyError = errors.New("some error")
+	var dummyBackoff = time.Millisecond
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRetryBackoff).Expects(gomocker.Anything(), 2).Returns(dummyBackoff).Once()
+	m.Mock(logMethodLogic).Expects(dummySession, LogLevelWarn, dummyName, "retrySession",
+		"Attempt [%v] of [%v] failed in phase [%v], retrying in [%s]. Error: %+v",
+		2, 3, RunPhasePostAction, dummyBackoff, dummyError).Returns().Once()
+
+	// SUT + act
+	var result = retrySession(
+		dummyApplication,
+		dummySession,
+		RunPhasePostAction,
+		dummyError,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, 3, dummySession.attempt)
+}
@@ -0,0 +1,73 @@
+package jobrunner
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// round holds the information of a single round of execution, shared by all instances of that round
+type round struct {
+	id        uuid.UUID
+	trigger   TriggerSource
+	reason    string
+	scheduled time.Time
+	instances int
+	work      <-chan *workItem
+	payloads  []any
+	upstream  map[string]*RoundRecord
+	ctx       context.Context
+	cancel    context.CancelFunc
+	cancelled atomic.Bool
+	lockedOut atomic.Bool
+	failed    atomic.Bool
+	span      *Span
+}
+
+func newRound(trigger TriggerSource, reason string, scheduled time.Time) *round {
+	return &round{
+		id:        uuid.New(),
+		trigger:   trigger,
+		reason:    reason,
+		scheduled: scheduled,
+	}
+}
+
+// RoundInfo is the information of a round of execution about to start, given to customizations deciding how the round is executed
+type RoundInfo struct {
+	// ID is the unique ID of the round
+	ID uuid.UUID
+	// Trigger is the source which triggered the round, e.g. scheduled, manual, upstream or catch-up
+	Trigger TriggerSource
+	// Reason is the reason given when the round was triggered manually
+	Reason string
+	// ScheduledTime is the time when the round became due
+	ScheduledTime time.Time
+}
+
+func newRoundInfo(round *round) RoundInfo {
+	return RoundInfo{
+		ID:            round.id,
+		Trigger:       round.trigger,
+		Reason:        round.reason,
+		ScheduledTime: round.scheduled,
+	}
+}
+
+// RoundCapStats is the snapshot of the rounds running under the cap of concurrent rounds, along with how often the cap was hit
+type RoundCapStats struct {
+	// Limit is the cap of concurrent rounds, or 0 if not capped
+	Limit int
+	// Running is the number of rounds currently running
+	Running int
+	// Waiting is the number of rounds currently queued for a free slot
+	Waiting int
+	// Hits is the number of rounds which became due while the cap was reached
+	Hits int
+	// Skipped is the number of rounds skipped due to the cap being reached
+	Skipped int
+	// Queued is the number of rounds queued due to the cap being reached
+	Queued int
+}
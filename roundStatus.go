@@ -0,0 +1,71 @@
+package jobrunner
+
+// RoundStatus is the status of a round of execution as recorded in history
+type RoundStatus int
+
+// These are the enum definitions of round statuses
+const (
+	RoundStatusCompleted RoundStatus = iota
+	RoundStatusCancelled
+	RoundStatusSkipped
+	RoundStatusCoalesced
+	RoundStatusUpstreamFailed
+	RoundStatusLocked
+)
+
+// These are the string representations of round statuses
+const (
+	completedRoundStatusName      string = "Completed"
+	cancelledRoundStatusName      string = "Cancelled"
+	skippedRoundStatusName        string = "Skipped"
+	coalescedRoundStatusName      string = "Coalesced"
+	upstreamFailedRoundStatusName string = "UpstreamFailed"
+	lockedRoundStatusName         string = "Locked"
+)
+
+var supportedRoundStatuses = map[RoundStatus]string{
+	RoundStatusCompleted:      completedRoundStatusName,
+	RoundStatusCancelled:      cancelledRoundStatusName,
+	RoundStatusSkipped:        skippedRoundStatusName,
+	RoundStatusCoalesced:      coalescedRoundStatusName,
+	RoundStatusUpstreamFailed: upstreamFailedRoundStatusName,
+	RoundStatusLocked:         lockedRoundStatusName,
+}
+
+var roundStatusNameMapping = map[string]RoundStatus{
+	completedRoundStatusName:      RoundStatusCompleted,
+	cancelledRoundStatusName:      RoundStatusCancelled,
+	skippedRoundStatusName:        RoundStatusSkipped,
+	coalescedRoundStatusName:      RoundStatusCoalesced,
+	upstreamFailedRoundStatusName: RoundStatusUpstreamFailed,
+	lockedRoundStatusName:         RoundStatusLocked,
+}
+
+// String converts a RoundStatus instance to its string representation
+func (roundStatus RoundStatus) String() string {
+	var name, found = supportedRoundStatuses[roundStatus]
+	if !found {
+		return completedRoundStatusName
+	}
+	return name
+}
+
+// NewRoundStatus converts a string representation of RoundStatus to its strongly typed instance
+func NewRoundStatus(value string) RoundStatus {
+	var roundStatus, found = roundStatusNameMapping[value]
+	if !found {
+		return RoundStatusCompleted
+	}
+	return roundStatus
+}
+
+// MarshalText converts a RoundStatus instance to its string representation for encodings like JSON
+func (roundStatus RoundStatus) MarshalText() ([]byte, error) {
+	return []byte(roundStatus.String()), nil
+}
+
+// UnmarshalText converts a string representation of RoundStatus from encodings like JSON to its strongly typed instance
+func (roundStatus *RoundStatus) UnmarshalText(text []byte) error {
+	*roundStatus = NewRoundStatus(string(text))
+	return nil
+}
@@ -0,0 +1,74 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundStatusString_NonSupportedRoundStatus(t *testing.T) {
+	// SUT
+	var sut = RoundStatus(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, completedRoundStatusName, result)
+}
+
+func TestRoundStatusString_SupportedRoundStatus(t *testing.T) {
+	// SUT
+	var sut = RoundStatusCoalesced
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, coalescedRoundStatusName, result)
+}
+
+func TestNewRoundStatus_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewRoundStatus(dummyValue)
+
+	// assert
+	assert.Equal(t, RoundStatusCompleted, result)
+}
+
+func TestNewRoundStatus_HappyPath(t *testing.T) {
+	for key, value := range roundStatusNameMapping {
+		// SUT + act
+		var result = NewRoundStatus(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
+
+func TestRoundStatusMarshalText(t *testing.T) {
+	// SUT
+	var sut = RoundStatusSkipped
+
+	// act
+	var result, err = sut.MarshalText()
+
+	// assert
+	assert.Equal(t, []byte("Skipped"), result)
+	assert.NoError(t, err)
+}
+
+func TestRoundStatusUnmarshalText(t *testing.T) {
+	// SUT
+	var sut RoundStatus
+
+	// act
+	var err = sut.UnmarshalText([]byte("Skipped"))
+
+	// assert
+	assert.Equal(t, RoundStatusSkipped, sut)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,62 @@
+package jobrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewRound(t *testing.T) {
+	// arrange
+	var dummyTrigger = TriggerSourceManual
+	var dummyReason = "some reason"
+	var dummyScheduled = time.Now()
+	var dummyID = uuid.New()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(uuid.New).Expects().Returns(dummyID).Once()
+
+	// SUT + act
+	var result = newRound(
+		dummyTrigger,
+		dummyReason,
+		dummyScheduled,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyID, result.id)
+	assert.Equal(t, dummyTrigger, result.trigger)
+	assert.Equal(t, dummyReason, result.reason)
+	assert.Equal(t, dummyScheduled, result.scheduled)
+	assert.Nil(t, result.ctx)
+	assert.Nil(t, result.cancel)
+}
+
+func TestNewRoundInfo(t *testing.T) {
+	// arrange
+	var dummyRound = &round{
+		id:        uuid.New(),
+		trigger:   TriggerSourceManual,
+		reason:    "some reason",
+		scheduled: time.Now(),
+		instances: 3,
+	}
+
+	// SUT + act
+	var result = newRoundInfo(
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummyRound.id, result.ID)
+	assert.Equal(t, dummyRound.trigger, result.Trigger)
+	assert.Equal(t, dummyRound.reason, result.Reason)
+	assert.Equal(t, dummyRound.scheduled, result.ScheduledTime)
+}
@@ -0,0 +1,184 @@
+package jobrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RunError is the structured record of an error occurred during the execution of the job runner
+type RunError struct {
+	// Timestamp is the time when the error was recorded
+	Timestamp time.Time
+	// RoundID is the ID of the round during which the error occurred, or uuid.Nil if not related to any round
+	RoundID uuid.UUID
+	// Index is the index of the instance during which the error occurred, or -1 if not related to any instance
+	Index int
+	// Reruns is the rerun count of the instance during which the error occurred
+	Reruns int
+	// Item is the sequence of the work item during which the error occurred in the work-queue mode, or -1 if not related to any work item
+	Item int
+	// Phase is the phase of the execution during which the error occurred
+	Phase RunPhase
+	// Err is the original error being recorded
+	Err error
+
+	sequence int
+}
+
+func newRunError(
+	round *round,
+	index int,
+	reruns int,
+	phase RunPhase,
+	err error,
+) *RunError {
+	var roundID = uuid.Nil
+	if round != nil {
+		roundID = round.id
+	}
+	return &RunError{
+		Timestamp: time.Now().UTC(),
+		RoundID:   roundID,
+		Index:     index,
+		Reruns:    reruns,
+		Item:      -1,
+		Phase:     phase,
+		Err:       err,
+	}
+}
+
+// runErrorJSON is the JSON representation of RunError, where the original error is kept as its message only
+type runErrorJSON struct {
+	Timestamp time.Time `json:"timestamp"`
+	RoundID   uuid.UUID `json:"roundId"`
+	Index     int       `json:"index"`
+	Reruns    int       `json:"reruns"`
+	Item      int       `json:"item"`
+	Phase     RunPhase  `json:"phase"`
+	Error     string    `json:"error"`
+}
+
+// Error returns the message of the original error prefixed by the phase of the execution
+func (runError *RunError) Error() string {
+	return fmt.Sprintf(
+		"[%v] %v",
+		runError.Phase,
+		runError.Err,
+	)
+}
+
+// Unwrap returns the original error being recorded
+func (runError *RunError) Unwrap() error {
+	return runError.Err
+}
+
+// MarshalJSON converts the run error to JSON, keeping only the message of the original error
+func (runError *RunError) MarshalJSON() ([]byte, error) {
+	var message string
+	if runError.Err != nil {
+		message = runError.Err.Error()
+	}
+	return json.Marshal(
+		runErrorJSON{
+			Timestamp: runError.Timestamp,
+			RoundID:   runError.RoundID,
+			Index:     runError.Index,
+			Reruns:    runError.Reruns,
+			Item:      runError.Item,
+			Phase:     runError.Phase,
+			Error:     message,
+		},
+	)
+}
+
+// UnmarshalJSON restores the run error from JSON, where the original error is restored from its message
+func (runError *RunError) UnmarshalJSON(data []byte) error {
+	var value = runErrorJSON{
+		Item: -1,
+	}
+	var unmarshalError = json.Unmarshal(
+		data,
+		&value,
+	)
+	if unmarshalError != nil {
+		return unmarshalError
+	}
+	runError.Timestamp = value.Timestamp
+	runError.RoundID = value.RoundID
+	runError.Index = value.Index
+	runError.Reruns = value.Reruns
+	runError.Item = value.Item
+	runError.Phase = value.Phase
+	runError.Err = errors.New(value.Error)
+	return nil
+}
+
+// errorRing is a bounded ring buffer of run errors, where the oldest records are overwritten once full
+type errorRing struct {
+	lock     sync.Mutex
+	records  []*RunError
+	head     int
+	count    int
+	recorded int
+}
+
+func newErrorRing(capacity int) *errorRing {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &errorRing{
+		records: make([]*RunError, capacity),
+	}
+}
+
+func (ring *errorRing) push(runError *RunError) {
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+	runError.sequence = ring.recorded
+	ring.recorded++
+	ring.records[ring.head] = runError
+	ring.head = (ring.head + 1) % len(ring.records)
+	if ring.count < len(ring.records) {
+		ring.count++
+	}
+}
+
+// filter returns the retained records matching the given predicate, from the oldest to the latest
+func (ring *errorRing) filter(predicate func(runError *RunError) bool) []*RunError {
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+	var result = []*RunError{}
+	var start = ring.head - ring.count + len(ring.records)
+	for offset := 0; offset < ring.count; offset++ {
+		var runError = ring.records[(start+offset)%len(ring.records)]
+		if predicate(runError) {
+			result = append(
+				result,
+				runError,
+			)
+		}
+	}
+	return result
+}
+
+// total returns the number of records ever pushed, including the overwritten ones
+func (ring *errorRing) total() int {
+	ring.lock.Lock()
+	defer ring.lock.Unlock()
+	return ring.recorded
+}
+
+func recordError(app *application, runError *RunError) {
+	app.errors.push(
+		runError,
+	)
+	observeRunError(
+		app,
+		runError,
+	)
+}
@@ -0,0 +1,327 @@
+package jobrunner
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewRunError_NilRound(t *testing.T) {
+	// arrange
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyPhase = RunPhaseAppClosing
+	var dummyError = errors.New("some error")
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+
+	// SUT + act
+	var result = newRunError(
+		nil,
+		dummyIndex,
+		dummyReruns,
+		dummyPhase,
+		dummyError,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyTimeNow.UTC(), result.Timestamp)
+	assert.Equal(t, uuid.Nil, result.RoundID)
+	assert.Equal(t, dummyIndex, result.Index)
+	assert.Equal(t, dummyReruns, result.Reruns)
+	assert.Equal(t, -1, result.Item)
+	assert.Equal(t, dummyPhase, result.Phase)
+	assert.Equal(t, dummyError, result.Err)
+}
+
+func TestNewRunError_ValidRound(t *testing.T) {
+	// arrange
+	var dummyRound = &round{id: uuid.New()}
+	var dummyIndex = rand.IntN(100)
+	var dummyReruns = rand.IntN(100)
+	var dummyPhase = RunPhaseAction
+	var dummyError = errors.New("some error")
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+
+	// SUT + act
+	var result = newRunError(
+		dummyRound,
+		dummyIndex,
+		dummyReruns,
+		dummyPhase,
+		dummyError,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyTimeNow.UTC(), result.Timestamp)
+	assert.Equal(t, dummyRound.id, result.RoundID)
+	assert.False(t, dummyRound.failed.Load())
+	assert.Equal(t, dummyIndex, result.Index)
+	assert.Equal(t, dummyReruns, result.Reruns)
+	assert.Equal(t, -1, result.Item)
+	assert.Equal(t, dummyPhase, result.Phase)
+	assert.Equal(t, dummyError, result.Err)
+}
+
+func TestRunError_Error(t *testing.T) {
+	// arrange
+	var dummyRunError = &RunError{
+		Phase: RunPhasePreAction,
+		Err:   errors.New("some error"),
+	}
+
+	// SUT + act
+	var result = dummyRunError.Error()
+
+	// assert
+	assert.Equal(t, "[PreAction] some error", result)
+}
+
+func TestRunError_Unwrap(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{
+		Err: dummyError,
+	}
+
+	// SUT + act
+	var result = dummyRunError.Unwrap()
+
+	// assert
+	assert.Equal(t, dummyError, result)
+	assert.ErrorIs(t, dummyRunError, dummyError)
+}
+
+func TestNewErrorRing_InvalidCapacity(t *testing.T) {
+	// SUT + act
+	var result = newErrorRing(0)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Len(t, result.records, 1)
+}
+
+func TestNewErrorRing_ValidCapacity(t *testing.T) {
+	// arrange
+	var dummyCapacity = rand.IntN(100) + 1
+
+	// SUT + act
+	var result = newErrorRing(dummyCapacity)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Len(t, result.records, dummyCapacity)
+}
+
+func TestErrorRing_Push(t *testing.T) {
+	// arrange
+	var dummyRunErrors = []*RunError{
+		{Index: 1},
+		{Index: 2},
+		{Index: 3},
+	}
+	var sut = newErrorRing(2)
+
+	// act
+	for _, runError := range dummyRunErrors {
+		sut.push(runError)
+	}
+
+	// assert
+	assert.Equal(t, 1, sut.head)
+	assert.Equal(t, 2, sut.count)
+	assert.Equal(t, 3, sut.recorded)
+	assert.Equal(t, []*RunError{dummyRunErrors[2], dummyRunErrors[1]}, sut.records)
+	assert.Equal(t, 0, dummyRunErrors[0].sequence)
+	assert.Equal(t, 1, dummyRunErrors[1].sequence)
+	assert.Equal(t, 2, dummyRunErrors[2].sequence)
+}
+
+func TestErrorRing_Filter_Empty(t *testing.T) {
+	// arrange
+	var sut = newErrorRing(2)
+
+	// act
+	var result = sut.filter(
+		func(runError *RunError) bool {
+			return true
+		},
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
+
+func TestErrorRing_Filter_Wrapped(t *testing.T) {
+	// arrange
+	var dummyRunErrors = []*RunError{
+		{Index: 1},
+		{Index: 2},
+		{Index: 3},
+		{Index: 4},
+		{Index: 5},
+	}
+	var sut = newErrorRing(3)
+
+	// stub
+	for _, runError := range dummyRunErrors {
+		sut.push(runError)
+	}
+
+	// act
+	var result = sut.filter(
+		func(runError *RunError) bool {
+			return runError.Index != 4
+		},
+	)
+
+	// assert
+	assert.Equal(t, []*RunError{dummyRunErrors[2], dummyRunErrors[4]}, result)
+}
+
+func TestErrorRing_Total(t *testing.T) {
+	// arrange
+	var sut = newErrorRing(1)
+
+	// stub
+	sut.push(&RunError{})
+	sut.push(&RunError{})
+
+	// act
+	var result = sut.total()
+
+	// assert
+	assert.Equal(t, 2, result)
+}
+
+func TestRecordError(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		errors: newErrorRing(10),
+	}
+	var dummyRunError = &RunError{}
+
+	// SUT + act
+	recordError(
+		dummyApplication,
+		dummyRunError,
+	)
+
+	// assert
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}
+
+func TestRunError_MarshalJSON_NilError(t *testing.T) {
+	// arrange
+	var dummyRunError = &RunError{
+		Timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		RoundID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Index:     1,
+		Reruns:    2,
+		Item:      -1,
+		Phase:     RunPhaseAction,
+	}
+
+	// SUT + act
+	var result, err = dummyRunError.MarshalJSON()
+
+	// assert
+	assert.Equal(t, `{"timestamp":"2021-01-01T00:00:00Z","roundId":"00000000-0000-0000-0000-000000000001","index":1,"reruns":2,"item":-1,"phase":"Action","error":""}`, string(result))
+	assert.NoError(t, err)
+}
+
+func TestRunError_MarshalJSON_ValidError(t *testing.T) {
+	// arrange
+	var dummyRunError = &RunError{
+		Timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		RoundID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Index:     1,
+		Reruns:    2,
+		Item:      3,
+		Phase:     RunPhaseConsumeWork,
+		Err:       errors.New("some error"),
+	}
+
+	// SUT + act
+	var result, err = dummyRunError.MarshalJSON()
+
+	// assert
+	assert.Equal(t, `{"timestamp":"2021-01-01T00:00:00Z","roundId":"00000000-0000-0000-0000-000000000001","index":1,"reruns":2,"item":3,"phase":"ConsumeWork","error":"some error"}`, string(result))
+	assert.NoError(t, err)
+}
+
+func TestRunError_UnmarshalJSON_InvalidData(t *testing.T) {
+	// arrange
+	var dummyData = []byte("some invalid data")
+
+	// SUT
+	var sut = &RunError{}
+
+	// act
+	var err = sut.UnmarshalJSON(dummyData)
+
+	// assert
+	assert.Error(t, err)
+	assert.Nil(t, sut.Err)
+}
+
+func TestRunError_UnmarshalJSON_NoItem(t *testing.T) {
+	// arrange
+	var dummyData = []byte(`{"timestamp":"2021-01-01T00:00:00Z","roundId":"00000000-0000-0000-0000-000000000001","index":1,"reruns":2,"phase":"Panic","error":"some error"}`)
+
+	// SUT
+	var sut = &RunError{}
+
+	// act
+	var err = sut.UnmarshalJSON(dummyData)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), sut.Timestamp)
+	assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000001"), sut.RoundID)
+	assert.Equal(t, 1, sut.Index)
+	assert.Equal(t, 2, sut.Reruns)
+	assert.Equal(t, -1, sut.Item)
+	assert.Equal(t, RunPhasePanic, sut.Phase)
+	assert.EqualError(t, sut.Err, "some error")
+}
+
+func TestRunError_UnmarshalJSON_ValidData(t *testing.T) {
+	// arrange
+	var dummyData = []byte(`{"timestamp":"2021-01-01T00:00:00Z","roundId":"00000000-0000-0000-0000-000000000001","index":1,"reruns":2,"item":3,"phase":"Panic","error":"some error"}`)
+
+	// SUT
+	var sut = &RunError{}
+
+	// act
+	var err = sut.UnmarshalJSON(dummyData)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), sut.Timestamp)
+	assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000001"), sut.RoundID)
+	assert.Equal(t, 1, sut.Index)
+	assert.Equal(t, 2, sut.Reruns)
+	assert.Equal(t, 3, sut.Item)
+	assert.Equal(t, RunPhasePanic, sut.Phase)
+	assert.EqualError(t, sut.Err, "some error")
+}
@@ -0,0 +1,78 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+)
+
+// RunHandle is the handle to a job runner started asynchronously, allowing it to be supervised like any other component
+type RunHandle interface {
+	// Done returns a channel that is closed once the job runner has terminated
+	Done() <-chan struct{}
+	// Wait blocks until the job runner has terminated and returns the aggregation of all errors occurred since it was started, or nil if none
+	Wait() error
+	// Stop interrupts the job runner hosting, causing the job runner to forcefully shutdown
+	Stop()
+}
+
+type runHandle struct {
+	app    *application
+	offset int
+	done   chan struct{}
+	cancel context.CancelFunc
+}
+
+func (app *application) StartAsync() RunHandle {
+	var ctx, cancel = context.WithCancel(
+		context.Background(),
+	)
+	var handle = &runHandle{
+		app:    app,
+		offset: app.errors.total(),
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	go runAsync(
+		handle,
+		ctx,
+	)
+	return handle
+}
+
+func runAsync(handle *runHandle, ctx context.Context) {
+	defer close(handle.done)
+	defer handle.cancel()
+	startApplication(
+		handle.app,
+		ctx,
+	)
+}
+
+// Done returns a channel that is closed once the job runner has terminated
+func (handle *runHandle) Done() <-chan struct{} {
+	return handle.done
+}
+
+// Wait blocks until the job runner has terminated and returns the aggregation of all errors occurred since it was started, or nil if none
+func (handle *runHandle) Wait() error {
+	<-handle.done
+	var runErrors = []error{}
+	for _, runError := range handle.app.errors.filter(
+		func(runError *RunError) bool {
+			return runError.sequence >= handle.offset
+		},
+	) {
+		runErrors = append(
+			runErrors,
+			runError,
+		)
+	}
+	return errors.Join(
+		runErrors...,
+	)
+}
+
+// Stop interrupts the job runner hosting, causing the job runner to forcefully shutdown
+func (handle *runHandle) Stop() {
+	handle.cancel()
+}
@@ -0,0 +1,156 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestApplication_StartAsync(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(1),
+	}
+	var dummyStarted = make(chan struct{})
+
+	// stub
+	dummyApplication.errors.push(&RunError{Err: errors.New("some error 1")})
+	dummyApplication.errors.push(&RunError{Err: errors.New("some error 2")})
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runAsync).Expects(gomocker.Anything(), gomocker.Anything()).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyStarted) })).Once()
+
+	// SUT + act
+	var result = dummyApplication.StartAsync()
+	var handle, ok = result.(*runHandle)
+
+	// assert
+	<-dummyStarted
+	assert.True(t, ok)
+	assert.Equal(t, dummyApplication, handle.app)
+	assert.Equal(t, 2, handle.offset)
+	assert.NotNil(t, handle.done)
+	assert.NotNil(t, handle.cancel)
+}
+
+func TestRunAsync(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyHandle = &runHandle{
+		app:    dummyApplication,
+		done:   make(chan struct{}),
+		cancel: dummyCancel,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startApplication).Expects(dummyApplication, dummyContext).Returns().Once()
+
+	// SUT + act
+	runAsync(
+		dummyHandle,
+		dummyContext,
+	)
+
+	// assert
+	var _, ok = <-dummyHandle.done
+	assert.False(t, ok)
+	assert.Error(t, dummyContext.Err())
+}
+
+func TestRunHandle_Done(t *testing.T) {
+	// arrange
+	var dummyDone = make(chan struct{})
+	var dummyHandle = &runHandle{
+		done: dummyDone,
+	}
+
+	// SUT + act
+	var result = dummyHandle.Done()
+
+	// assert
+	assert.Equal(t, (<-chan struct{})(dummyDone), result)
+}
+
+func TestRunHandle_Wait_NoErrors(t *testing.T) {
+	// arrange
+	var dummyDone = make(chan struct{})
+	var dummyApplication = &application{
+		errors: newErrorRing(10),
+	}
+	var dummyHandle = &runHandle{
+		app:    dummyApplication,
+		offset: 1,
+		done:   dummyDone,
+	}
+
+	// stub
+	dummyApplication.errors.push(&RunError{Err: errors.New("some error 1")})
+	close(dummyDone)
+
+	// SUT + act
+	var err = dummyHandle.Wait()
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestRunHandle_Wait_WithErrors(t *testing.T) {
+	// arrange
+	var dummyDone = make(chan struct{})
+	var dummyErrors = []error{
+		errors.New("some error 1"),
+		errors.New("some error 2"),
+		errors.New("some error 3"),
+	}
+	var dummyApplication = &application{
+		errors: newErrorRing(10),
+	}
+	var dummyHandle = &runHandle{
+		app:    dummyApplication,
+		offset: 1,
+		done:   dummyDone,
+	}
+
+	// stub
+	for _, dummyError := range dummyErrors {
+		dummyApplication.errors.push(&RunError{Err: dummyError})
+	}
+	close(dummyDone)
+
+	// SUT + act
+	var err = dummyHandle.Wait()
+
+	// assert
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, dummyErrors[0])
+	assert.ErrorIs(t, err, dummyErrors[1])
+	assert.ErrorIs(t, err, dummyErrors[2])
+}
+
+func TestRunHandle_Stop(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyHandle = &runHandle{
+		cancel: dummyCancel,
+	}
+
+	// SUT + act
+	dummyHandle.Stop()
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+}
@@ -0,0 +1,107 @@
+package jobrunner
+
+// RunPhase is the phase of the job runner execution during which an error occurred
+type RunPhase int
+
+// These are the enum definitions of run phases
+const (
+	RunPhasePreBootstrap RunPhase = iota
+	RunPhasePostBootstrap
+	RunPhasePreAction
+	RunPhaseAction
+	RunPhasePostAction
+	RunPhasePanic
+	RunPhaseTerminate
+	RunPhaseReload
+	RunPhaseAppClosing
+	RunPhaseProduceWork
+	RunPhaseConsumeWork
+	RunPhaseTimeout
+	RunPhaseStage
+	RunPhaseLock
+	RunPhaseAdmin
+)
+
+// These are the string representations of run phases
+const (
+	preBootstrapRunPhaseName  string = "PreBootstrap"
+	postBootstrapRunPhaseName string = "PostBootstrap"
+	preActionRunPhaseName     string = "PreAction"
+	actionRunPhaseName        string = "Action"
+	postActionRunPhaseName    string = "PostAction"
+	panicRunPhaseName         string = "Panic"
+	terminateRunPhaseName     string = "Terminate"
+	reloadRunPhaseName        string = "Reload"
+	appClosingRunPhaseName    string = "AppClosing"
+	produceWorkRunPhaseName   string = "ProduceWork"
+	consumeWorkRunPhaseName   string = "ConsumeWork"
+	timeoutRunPhaseName       string = "Timeout"
+	stageRunPhaseName         string = "Stage"
+	lockRunPhaseName          string = "Lock"
+	adminRunPhaseName         string = "Admin"
+)
+
+var supportedRunPhases = map[RunPhase]string{
+	RunPhasePreBootstrap:  preBootstrapRunPhaseName,
+	RunPhasePostBootstrap: postBootstrapRunPhaseName,
+	RunPhasePreAction:     preActionRunPhaseName,
+	RunPhaseAction:        actionRunPhaseName,
+	RunPhasePostAction:    postActionRunPhaseName,
+	RunPhasePanic:         panicRunPhaseName,
+	RunPhaseTerminate:     terminateRunPhaseName,
+	RunPhaseReload:        reloadRunPhaseName,
+	RunPhaseAppClosing:    appClosingRunPhaseName,
+	RunPhaseProduceWork:   produceWorkRunPhaseName,
+	RunPhaseConsumeWork:   consumeWorkRunPhaseName,
+	RunPhaseTimeout:       timeoutRunPhaseName,
+	RunPhaseStage:         stageRunPhaseName,
+	RunPhaseLock:          lockRunPhaseName,
+	RunPhaseAdmin:         adminRunPhaseName,
+}
+
+var runPhaseNameMapping = map[string]RunPhase{
+	preBootstrapRunPhaseName:  RunPhasePreBootstrap,
+	postBootstrapRunPhaseName: RunPhasePostBootstrap,
+	preActionRunPhaseName:     RunPhasePreAction,
+	actionRunPhaseName:        RunPhaseAction,
+	postActionRunPhaseName:    RunPhasePostAction,
+	panicRunPhaseName:         RunPhasePanic,
+	terminateRunPhaseName:     RunPhaseTerminate,
+	reloadRunPhaseName:        RunPhaseReload,
+	appClosingRunPhaseName:    RunPhaseAppClosing,
+	produceWorkRunPhaseName:   RunPhaseProduceWork,
+	consumeWorkRunPhaseName:   RunPhaseConsumeWork,
+	timeoutRunPhaseName:       RunPhaseTimeout,
+	stageRunPhaseName:         RunPhaseStage,
+	lockRunPhaseName:          RunPhaseLock,
+	adminRunPhaseName:         RunPhaseAdmin,
+}
+
+// String converts a RunPhase instance to its string representation
+func (runPhase RunPhase) String() string {
+	var name, found = supportedRunPhases[runPhase]
+	if !found {
+		return actionRunPhaseName
+	}
+	return name
+}
+
+// NewRunPhase converts a string representation of RunPhase to its strongly typed instance
+func NewRunPhase(value string) RunPhase {
+	var runPhase, found = runPhaseNameMapping[value]
+	if !found {
+		return RunPhaseAction
+	}
+	return runPhase
+}
+
+// MarshalText converts a RunPhase instance to its string representation for encodings like JSON
+func (runPhase RunPhase) MarshalText() ([]byte, error) {
+	return []byte(runPhase.String()), nil
+}
+
+// UnmarshalText converts a string representation of RunPhase from encodings like JSON to its strongly typed instance
+func (runPhase *RunPhase) UnmarshalText(text []byte) error {
+	*runPhase = NewRunPhase(string(text))
+	return nil
+}
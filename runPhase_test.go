@@ -0,0 +1,74 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPhaseString_NonSupportedRunPhase(t *testing.T) {
+	// SUT
+	var sut = RunPhase(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, actionRunPhaseName, result)
+}
+
+func TestRunPhaseString_SupportedRunPhase(t *testing.T) {
+	// SUT
+	var sut = RunPhasePanic
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, panicRunPhaseName, result)
+}
+
+func TestNewRunPhase_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewRunPhase(dummyValue)
+
+	// assert
+	assert.Equal(t, RunPhaseAction, result)
+}
+
+func TestNewRunPhase_HappyPath(t *testing.T) {
+	for key, value := range runPhaseNameMapping {
+		// SUT + act
+		var result = NewRunPhase(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
+
+func TestRunPhaseMarshalText(t *testing.T) {
+	// SUT
+	var sut = RunPhasePanic
+
+	// act
+	var result, err = sut.MarshalText()
+
+	// assert
+	assert.Equal(t, []byte("Panic"), result)
+	assert.NoError(t, err)
+}
+
+func TestRunPhaseUnmarshalText(t *testing.T) {
+	// SUT
+	var sut RunPhase
+
+	// act
+	var err = sut.UnmarshalText([]byte("Panic"))
+
+	// assert
+	assert.Equal(t, RunPhasePanic, sut)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,266 @@
+package jobrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Runner is the interface for hosting several named jobs in one process, where the jobs share the bootstrapping, the HTTP client configuration, the signal handling and the shutdown of the runner
+type Runner interface {
+	// Register registers a named job with its own number of instances, schedule, overlap policy and customization; returns error if the name is already registered or the runner is running
+	Register(name string, instances int, schedule Schedule, overlap OverlapPolicy, customization Customization) error
+	// DependsOn declares the named job to run a round each time all the given upstream jobs have completed a round, instead of following its own schedule; the round is skipped if any of the upstream rounds failed, which also propagates to further downstream jobs; returns error if any job is not registered, the dependency closes a cycle, or the runner is running
+	DependsOn(name string, upstreams ...string) error
+	// Start bootstraps the runner and starts all registered jobs, blocking until all of them have terminated
+	Start()
+	// StartContext starts the runner the same way as Start does, but bound to the given context; cancelling the context stops the runner as if Stop was called
+	StartContext(ctx context.Context)
+	// IsRunning returns true if the runner has been successfully started and is currently running
+	IsRunning() bool
+	// RunErrors returns the retained run error records of the runner itself, e.g. bootstrapping, reload or closing, from the oldest to the latest; errors of each job are reported through the job instead
+	RunErrors() []*RunError
+	// Stop interrupts the runner, causing all jobs to forcefully shutdown and the contexts of all running sessions to be cancelled
+	Stop()
+	// StopGracefully stops scheduling new rounds for all jobs and waits for their running instances to complete up to the given timeout, after which the remaining instances are cancelled and reported in the errors of their jobs
+	StopGracefully(timeout time.Duration)
+	// Jobs returns the names of the registered jobs, in the order of registration
+	Jobs() []string
+	// Job returns the application of the named job for querying its execution, e.g. History, LastErrors, etc., or false if no job is registered with the name; the returned application could not be started on its own
+	Job(name string) (Application, bool)
+	// Status returns the snapshot of the status of the named job, or false if no job is registered with the name
+	Status(name string) (JobStatus, bool)
+}
+
+// JobStatus is the snapshot of the status of a job hosted by a Runner
+type JobStatus struct {
+	// Name is the name of the job
+	Name string
+	// Running is whether the job is currently running
+	Running bool
+	// Paused is whether the job is currently paused
+	Paused bool
+	// RunningRounds is the number of rounds of the job currently running
+	RunningRounds int
+	// BreakerState is the current state of the circuit breaker of the job
+	BreakerState BreakerState
+	// Errors is the total number of run errors recorded for the job since created
+	Errors int
+	// LastRound is the record of the latest completed round of the job, or nil if none completed yet
+	LastRound *RoundRecord
+}
+
+type runner struct {
+	app *application
+}
+
+// NewRunner creates a new runner for hosting several named jobs in one process
+//
+//	customization is used for the bootstrapping, the HTTP client configuration, the signal handling and the closing shared by all jobs, which are not taken from the customizations of the registered jobs
+func NewRunner(
+	name string,
+	version string,
+	customization Customization,
+) Runner {
+	var app = newApplication(
+		name,
+		version,
+		0,
+		nil,
+		OverlapPolicyAllow,
+		customization,
+	)
+	app.jobs = []*application{}
+	return &runner{
+		app: app,
+	}
+}
+
+func (runner *runner) Register(
+	name string,
+	instances int,
+	schedule Schedule,
+	overlap OverlapPolicy,
+	customization Customization,
+) error {
+	return registerJob(
+		runner.app,
+		newApplication(
+			name,
+			runner.app.version,
+			instances,
+			schedule,
+			overlap,
+			customization,
+		),
+	)
+}
+
+func (runner *runner) DependsOn(name string, upstreams ...string) error {
+	return registerDependency(
+		runner.app,
+		name,
+		upstreams,
+	)
+}
+
+func (runner *runner) Start() {
+	startApplication(
+		runner.app,
+		context.Background(),
+	)
+}
+
+func (runner *runner) StartContext(ctx context.Context) {
+	startApplication(
+		runner.app,
+		ctx,
+	)
+}
+
+func (runner *runner) IsRunning() bool {
+	return runner.app.IsRunning()
+}
+
+func (runner *runner) RunErrors() []*RunError {
+	return runner.app.RunErrors()
+}
+
+func (runner *runner) Stop() {
+	runner.app.Stop()
+}
+
+func (runner *runner) StopGracefully(timeout time.Duration) {
+	runner.app.StopGracefully(
+		timeout,
+	)
+}
+
+func (runner *runner) Jobs() []string {
+	runner.app.lock.Lock()
+	defer runner.app.lock.Unlock()
+	var names = []string{}
+	for _, job := range runner.app.jobs {
+		names = append(
+			names,
+			job.name,
+		)
+	}
+	return names
+}
+
+func (runner *runner) Job(name string) (Application, bool) {
+	var job = findJob(
+		runner.app,
+		name,
+	)
+	if job == nil {
+		return nil, false
+	}
+	return job, true
+}
+
+func (runner *runner) Status(name string) (JobStatus, bool) {
+	var job = findJob(
+		runner.app,
+		name,
+	)
+	if job == nil {
+		return JobStatus{}, false
+	}
+	return getJobStatus(job), true
+}
+
+// findJob returns the registered job with the given name, or nil if not found
+func findJob(app *application, name string) *application {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	return lookupJob(
+		app,
+		name,
+	)
+}
+
+// lookupJob returns the registered job with the given name, or nil if not found; the application lock must be held by the caller
+func lookupJob(app *application, name string) *application {
+	for _, job := range app.jobs {
+		if job.name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+func registerJob(app *application, job *application) error {
+	app.lock.Lock()
+	defer app.lock.Unlock()
+	if app.started {
+		return fmt.Errorf(
+			"Runner [%v] is running, unable to register job [%v]",
+			app.name,
+			job.name,
+		)
+	}
+	if lookupJob(
+		app,
+		job.name,
+	) != nil {
+		return fmt.Errorf(
+			"Runner [%v] already has job [%v] registered",
+			app.name,
+			job.name,
+		)
+	}
+	job.host = app
+	app.jobs = append(
+		app.jobs,
+		job,
+	)
+	return nil
+}
+
+func getJobStatus(job *application) JobStatus {
+	var status = JobStatus{
+		Name:         job.name,
+		Running:      job.IsRunning(),
+		Paused:       job.IsPaused(),
+		BreakerState: job.BreakerState(),
+		Errors:       job.errors.total(),
+	}
+	status.RunningRounds = job.RoundCapStats().Running
+	var records = job.History()
+	if len(records) > 0 {
+		status.LastRound = records[len(records)-1]
+	}
+	return status
+}
+
+// runJobs starts all registered jobs in parallel within the hosting application, and waits for all of them to terminate
+func runJobs(app *application) {
+	var waitGroup sync.WaitGroup
+	for _, job := range app.jobs {
+		waitGroup.Add(1)
+		go func(job *application) {
+			defer waitGroup.Done()
+			hostJob(
+				app,
+				job,
+			)
+		}(job)
+	}
+	waitGroup.Wait()
+}
+
+// hostJob starts the job with the HTTP clients of the hosting application, and blocks until the job terminates; the job is stopped along with the hosting application
+func hostJob(app *application, job *application) {
+	job.clients = app.clients
+	job.session.clients = app.clients
+	configureApplication(
+		job,
+	)
+	beginApplication(
+		job,
+		app.ctx,
+	)
+}
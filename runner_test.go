@@ -0,0 +1,576 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewRunner(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyVersion = "some version"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{name: dummyName}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newApplication).Expects(dummyName, dummyVersion, 0, nil, OverlapPolicyAllow, dummyCustomization).Returns(dummyApplication).Once()
+
+	// SUT
+	var result = NewRunner(
+		dummyName,
+		dummyVersion,
+		dummyCustomization,
+	)
+
+	// act
+	var value, ok = result.(*runner)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyApplication, value.app)
+	assert.NotNil(t, dummyApplication.jobs)
+	assert.Empty(t, dummyApplication.jobs)
+}
+
+func TestRunner_Register(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyVersion = "some version"
+	var dummyInstances = rand.IntN(100)
+	type schedule struct {
+		Schedule
+	}
+	var dummySchedule = &schedule{}
+	var dummyOverlap = OverlapPolicy(rand.IntN(4))
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{version: dummyVersion}
+	var dummyJob = &application{name: dummyName}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newApplication).Expects(dummyName, dummyVersion, dummyInstances, dummySchedule, dummyOverlap, dummyCustomization).Returns(dummyJob).Once()
+	m.Mock(registerJob).Expects(dummyApplication, dummyJob).Returns(dummyError).Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var err = sut.Register(
+		dummyName,
+		dummyInstances,
+		dummySchedule,
+		dummyOverlap,
+		dummyCustomization,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestRunner_DependsOn(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyName = "some job"
+	var dummyUpstreams = []string{"upstream 1", "upstream 2"}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(registerDependency).Expects(dummyApplication, dummyName, dummyUpstreams).Returns(dummyError).Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var err = sut.DependsOn(
+		dummyName,
+		dummyUpstreams...,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestRunner_Start(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startApplication).Expects(dummyApplication, context.Background()).Returns().Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	sut.Start()
+}
+
+func TestRunner_StartContext(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyContext = context.TODO()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startApplication).Expects(dummyApplication, dummyContext).Returns().Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	sut.StartContext(dummyContext)
+}
+
+func TestRunner_IsRunning(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		started: true,
+	}
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result = sut.IsRunning()
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestRunner_RunErrors(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+	var dummyRunError = newRunError(nil, -1, 0, RunPhasePreBootstrap, errors.New("some error"))
+
+	// stub
+	recordError(dummyApplication, dummyRunError)
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result = sut.RunErrors()
+
+	// assert
+	assert.Equal(t, []*RunError{dummyRunError}, result)
+}
+
+func TestRunner_Stop(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyApplication = &application{
+		name:    "some name",
+		started: true,
+		cancel:  dummyCancel,
+	}
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	sut.Stop()
+
+	// assert
+	assert.Error(t, dummyContext.Err())
+}
+
+func TestRunner_StopGracefully(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		started: true,
+	}
+	var dummyTimeout = time.Duration(rand.IntN(1000))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(stopGracefully).Expects(dummyApplication, dummyTimeout).Returns().Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	sut.StopGracefully(dummyTimeout)
+}
+
+func TestRunner_Jobs(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{
+			{name: "job 1"},
+			{name: "job 2"},
+		},
+	}
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result = sut.Jobs()
+
+	// assert
+	assert.Equal(t, []string{"job 1", "job 2"}, result)
+}
+
+func TestRunner_Job_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyName = "some job"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(findJob).Expects(dummyApplication, dummyName).Returns(nil).Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result, ok = sut.Job(dummyName)
+
+	// assert
+	assert.False(t, ok)
+	assert.Nil(t, result)
+}
+
+func TestRunner_Job_Found(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyName = "some job"
+	var dummyJob = &application{name: dummyName}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(findJob).Expects(dummyApplication, dummyName).Returns(dummyJob).Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result, ok = sut.Job(dummyName)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyJob, result)
+}
+
+func TestRunner_Status_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyName = "some job"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(findJob).Expects(dummyApplication, dummyName).Returns(nil).Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result, ok = sut.Status(dummyName)
+
+	// assert
+	assert.False(t, ok)
+	assert.Zero(t, result)
+}
+
+func TestRunner_Status_Found(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{name: "some name"}
+	var dummyName = "some job"
+	var dummyJob = &application{name: dummyName}
+	var dummyStatus = JobStatus{Name: dummyName, Running: true}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(findJob).Expects(dummyApplication, dummyName).Returns(dummyJob).Once()
+	m.Mock(getJobStatus).Expects(dummyJob).Returns(dummyStatus).Once()
+
+	// SUT
+	var sut = &runner{
+		app: dummyApplication,
+	}
+
+	// act
+	var result, ok = sut.Status(dummyName)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyStatus, result)
+}
+
+func TestFindJob_NotFound(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{
+			{name: "job 1"},
+		},
+	}
+
+	// SUT + act
+	var result = findJob(
+		dummyApplication,
+		"job 2",
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestFindJob_Found(t *testing.T) {
+	// arrange
+	var dummyJob = &application{name: "job 2"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{
+			{name: "job 1"},
+			dummyJob,
+		},
+	}
+
+	// SUT + act
+	var result = findJob(
+		dummyApplication,
+		"job 2",
+	)
+
+	// assert
+	assert.Equal(t, dummyJob, result)
+}
+
+func TestRegisterJob_Running(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:    "some name",
+		started: true,
+		jobs:    []*application{},
+	}
+	var dummyJob = &application{name: "some job"}
+
+	// SUT + act
+	var err = registerJob(
+		dummyApplication,
+		dummyJob,
+	)
+
+	// assert
+	assert.EqualError(t, err, "Runner [some name] is running, unable to register job [some job]")
+	assert.Empty(t, dummyApplication.jobs)
+	assert.Nil(t, dummyJob.host)
+}
+
+func TestRegisterJob_Duplicated(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{
+			{name: "some job"},
+		},
+	}
+	var dummyJob = &application{name: "some job"}
+
+	// SUT + act
+	var err = registerJob(
+		dummyApplication,
+		dummyJob,
+	)
+
+	// assert
+	assert.EqualError(t, err, "Runner [some name] already has job [some job] registered")
+	assert.Len(t, dummyApplication.jobs, 1)
+	assert.Nil(t, dummyJob.host)
+}
+
+func TestRegisterJob_Success(t *testing.T) {
+	// arrange
+	var dummyExisting = &application{name: "other job"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyExisting},
+	}
+	var dummyJob = &application{name: "some job"}
+
+	// SUT + act
+	var err = registerJob(
+		dummyApplication,
+		dummyJob,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*application{dummyExisting, dummyJob}, dummyApplication.jobs)
+	assert.Equal(t, dummyApplication, dummyJob.host)
+}
+
+func TestGetJobStatus_NoRound(t *testing.T) {
+	// arrange
+	var dummyJob = &application{
+		name:    "some job",
+		errors:  newErrorRing(10),
+		history: newRoundHistory(10),
+		rounds:  map[uuid.UUID]*round{},
+	}
+
+	// SUT + act
+	var result = getJobStatus(
+		dummyJob,
+	)
+
+	// assert
+	assert.Equal(t, JobStatus{Name: "some job"}, result)
+}
+
+func TestGetJobStatus_WithRounds(t *testing.T) {
+	// arrange
+	var dummyRunning = &round{id: uuid.New()}
+	var dummyJob = &application{
+		name:         "some job",
+		started:      true,
+		paused:       true,
+		breakerState: BreakerStateOpen,
+		errors:       newErrorRing(10),
+		history:      newRoundHistory(10),
+		rounds:       map[uuid.UUID]*round{dummyRunning.id: dummyRunning},
+	}
+	var dummyRecord1 = &RoundRecord{ID: uuid.New()}
+	var dummyRecord2 = &RoundRecord{ID: uuid.New()}
+
+	// stub
+	recordError(dummyJob, newRunError(nil, -1, 0, RunPhaseAction, errors.New("some error")))
+	dummyJob.history.add(dummyRecord1)
+	dummyJob.history.add(dummyRecord2)
+
+	// SUT + act
+	var result = getJobStatus(
+		dummyJob,
+	)
+
+	// assert
+	assert.Equal(t, "some job", result.Name)
+	assert.True(t, result.Running)
+	assert.True(t, result.Paused)
+	assert.Equal(t, 1, result.RunningRounds)
+	assert.Equal(t, BreakerStateOpen, result.BreakerState)
+	assert.Equal(t, 1, result.Errors)
+	assert.Equal(t, dummyRecord2, result.LastRound)
+}
+
+func TestRunJobs(t *testing.T) {
+	// arrange
+	var dummyJob1 = &application{name: "job 1"}
+	var dummyJob2 = &application{name: "job 2"}
+	var dummyApplication = &application{
+		name: "some name",
+		jobs: []*application{dummyJob1, dummyJob2},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(hostJob).Expects(dummyApplication, dummyJob1).Returns().Once()
+	m.Mock(hostJob).Expects(dummyApplication, dummyJob2).Returns().Once()
+
+	// SUT + act
+	runJobs(
+		dummyApplication,
+	)
+}
+
+func TestHostJob(t *testing.T) {
+	// arrange
+	var dummyClients = &httpClients{}
+	var dummyContext = context.TODO()
+	var dummyApplication = &application{
+		name:    "some name",
+		clients: dummyClients,
+		ctx:     dummyContext,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyJob = &application{
+		name:    "some job",
+		session: dummySession,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(configureApplication).Expects(dummyJob).Returns().Once()
+	m.Mock(beginApplication).Expects(dummyJob, dummyContext).Returns().Once()
+
+	// SUT + act
+	hostJob(
+		dummyApplication,
+		dummyJob,
+	)
+
+	// assert
+	assert.Equal(t, dummyClients, dummyJob.clients)
+	assert.Equal(t, dummyClients, dummySession.clients)
+}
@@ -1,9 +1,12 @@
 package jobrunner
 
 import (
+	"context"
+	"maps"
 	"reflect"
 	"runtime"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -26,6 +29,36 @@ type SessionMeta interface {
 
 	// GetReruns returns the rerun count for the same instance since first scheduled
 	GetReruns() int
+
+	// GetAttempt returns the attempt of the current execution within the round, starting from 1 and advanced upon each retry according to the customized retry policy
+	GetAttempt() int
+
+	// GetInstanceCount returns the total number of instances executed in parallel in the round of execution of the session
+	GetInstanceCount() int
+
+	// GetShardCount returns the total number of shards of the round of execution of the session, where the instance index is the shard index
+	GetShardCount() int
+
+	// GetShardPayload returns the data assigned to the shard of the session by the customized Partition, or nil if none assigned
+	GetShardPayload() any
+
+	// GetUpstreamRounds returns the records of the upstream rounds which triggered the round of execution of the session, keyed by the names of the upstream jobs, or nil if not triggered by upstream jobs
+	GetUpstreamRounds() map[string]*RoundRecord
+
+	// GetWorkItem returns the sequence of the work item processed by the session in the work-queue mode, or -1 if the session is not processing any work item
+	GetWorkItem() int
+
+	// Context returns the context of the session, which is cancelled when the application stops or the round of execution is abandoned
+	Context() context.Context
+
+	// GetTrigger returns the source which triggered the round of execution of the session, e.g. scheduled, manual, upstream or catch-up
+	GetTrigger() TriggerSource
+
+	// GetTriggerReason returns the reason given when the round of execution of the session was triggered manually
+	GetTriggerReason() string
+
+	// GetScheduledTime returns the time when the round of execution of the session became due, e.g. the original scheduled time of a round catching up a missed slot
+	GetScheduledTime() time.Time
 }
 
 // SessionAttachment is a subset of Session interface, containing only attachment related methods
@@ -71,8 +104,14 @@ type session struct {
 	id            uuid.UUID
 	index         int
 	reruns        int
+	attempt       int
+	ctx           context.Context
+	round         *round
+	item          *workItem
 	attachment    map[string]any
+	clients       *httpClients
 	customization Customization
+	span          *Span
 }
 
 // GetID returns the ID of this registered session object
@@ -99,6 +138,93 @@ func (session *session) GetReruns() int {
 	return session.reruns
 }
 
+// GetInstanceCount returns the total number of instances executed in parallel in the round of execution of the session
+func (session *session) GetInstanceCount() int {
+	if session == nil ||
+		session.round == nil {
+		return 0
+	}
+	return session.round.instances
+}
+
+// GetShardCount returns the total number of shards of the round of execution of the session, where the instance index is the shard index
+func (session *session) GetShardCount() int {
+	return session.GetInstanceCount()
+}
+
+// GetShardPayload returns the data assigned to the shard of the session by the customized Partition, or nil if none assigned
+func (session *session) GetShardPayload() any {
+	if session == nil ||
+		session.round == nil ||
+		session.index < 0 ||
+		session.index >= len(session.round.payloads) {
+		return nil
+	}
+	return session.round.payloads[session.index]
+}
+
+// GetWorkItem returns the sequence of the work item processed by the session in the work-queue mode, or -1 if the session is not processing any work item
+func (session *session) GetWorkItem() int {
+	if session == nil ||
+		session.item == nil {
+		return -1
+	}
+	return session.item.sequence
+}
+
+// GetAttempt returns the attempt of the current execution within the round, starting from 1 and advanced upon each retry according to the customized retry policy
+func (session *session) GetAttempt() int {
+	if session == nil {
+		return 0
+	}
+	return session.attempt
+}
+
+// Context returns the context of the session, which is cancelled when the application stops or the round of execution is abandoned
+func (session *session) Context() context.Context {
+	if session == nil ||
+		session.ctx == nil {
+		return context.Background()
+	}
+	return session.ctx
+}
+
+// GetUpstreamRounds returns the records of the upstream rounds which triggered the round of execution of the session, keyed by the names of the upstream jobs, or nil if not triggered by upstream jobs
+func (session *session) GetUpstreamRounds() map[string]*RoundRecord {
+	if session == nil ||
+		session.round == nil {
+		return nil
+	}
+	return maps.Clone(session.round.upstream)
+}
+
+// GetTrigger returns the source which triggered the round of execution of the session, e.g. scheduled, manual, upstream or catch-up
+func (session *session) GetTrigger() TriggerSource {
+	if session == nil ||
+		session.round == nil {
+		return TriggerSourceScheduled
+	}
+	return session.round.trigger
+}
+
+// GetTriggerReason returns the reason given when the round of execution of the session was triggered manually
+func (session *session) GetTriggerReason() string {
+	if session == nil ||
+		session.round == nil {
+		return ""
+	}
+	return session.round.reason
+}
+
+// GetScheduledTime returns the time when the round of execution of the session became due, e.g. the original scheduled time of a round catching up a missed slot
+func (session *session) GetScheduledTime() time.Time {
+	if session == nil ||
+		session.round == nil {
+		return time.Time{}
+	}
+	return session.round.scheduled
+}
+
 // Attach attaches any value object into the given session associated to the session ID
 func (session *session) Attach(name string, value any) bool {
 	if session == nil {
@@ -256,5 +382,6 @@ func (session *session) CreateWebcallRequest(
 		sendClientCert,
 		0,
 		[]dataReceiver{},
+		nil,
 	}
 }
@@ -1,10 +1,12 @@
 package jobrunner
 
 import (
+	"context"
 	"errors"
 	"math/rand/v2"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -92,6 +94,401 @@ func TestSessionGetReruns_ValidSessionObject(t *testing.T) {
 	assert.Equal(t, dummyIndex, result)
 }
 
+func TestSessionGetAttempt_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetAttempt()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetAttempt_ValidSessionObject(t *testing.T) {
+	// arrange
+	var dummyAttempt = rand.IntN(10)
+
+	// SUT
+	var dummySession = &session{
+		attempt: dummyAttempt,
+	}
+
+	// act
+	var result = dummySession.GetAttempt()
+
+	// assert
+	assert.Equal(t, dummyAttempt, result)
+}
+
+func TestSessionGetInstanceCount_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetInstanceCount()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetInstanceCount_NilRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetInstanceCount()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetInstanceCount_ValidRound(t *testing.T) {
+	// arrange
+	var dummyInstances = rand.IntN(100)
+
+	// SUT
+	var dummySession = &session{
+		round: &round{
+			instances: dummyInstances,
+		},
+	}
+
+	// act
+	var result = dummySession.GetInstanceCount()
+
+	// assert
+	assert.Equal(t, dummyInstances, result)
+}
+
+func TestSessionGetShardCount(t *testing.T) {
+	// arrange
+	var dummyInstances = rand.IntN(100)
+
+	// SUT
+	var dummySession = &session{
+		round: &round{
+			instances: dummyInstances,
+		},
+	}
+
+	// act
+	var result = dummySession.GetShardCount()
+
+	// assert
+	assert.Equal(t, dummyInstances, result)
+}
+
+func TestSessionGetShardPayload_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetShardPayload()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetShardPayload_NilRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetShardPayload()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetShardPayload_NegativeIndex(t *testing.T) {
+	// SUT
+	var dummySession = &session{
+		index: -1,
+		round: &round{
+			payloads: []any{"some payload"},
+		},
+	}
+
+	// act
+	var result = dummySession.GetShardPayload()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetShardPayload_IndexOutOfRange(t *testing.T) {
+	// SUT
+	var dummySession = &session{
+		index: 1,
+		round: &round{
+			payloads: []any{"some payload"},
+		},
+	}
+
+	// act
+	var result = dummySession.GetShardPayload()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetShardPayload_ValidIndex(t *testing.T) {
+	// SUT
+	var dummySession = &session{
+		index: 1,
+		round: &round{
+			payloads: []any{"some payload 1", "some payload 2"},
+		},
+	}
+
+	// act
+	var result = dummySession.GetShardPayload()
+
+	// assert
+	assert.Equal(t, "some payload 2", result)
+}
+
+func TestSessionGetWorkItem_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetWorkItem()
+
+	// assert
+	assert.Equal(t, -1, result)
+}
+
+func TestSessionGetWorkItem_NilItem(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetWorkItem()
+
+	// assert
+	assert.Equal(t, -1, result)
+}
+
+func TestSessionGetWorkItem_ValidItem(t *testing.T) {
+	// arrange
+	var dummySequence = rand.IntN(100)
+
+	// SUT
+	var dummySession = &session{
+		item: &workItem{
+			sequence: dummySequence,
+		},
+	}
+
+	// act
+	var result = dummySession.GetWorkItem()
+
+	// assert
+	assert.Equal(t, dummySequence, result)
+}
+
+func TestSessionContext_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.Context()
+
+	// assert
+	assert.Equal(t, context.Background(), result)
+}
+
+func TestSessionContext_NilContext(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.Context()
+
+	// assert
+	assert.Equal(t, context.Background(), result)
+}
+
+func TestSessionContext_ValidContext(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	defer dummyCancel()
+
+	// SUT
+	var dummySession = &session{
+		ctx: dummyContext,
+	}
+
+	// act
+	var result = dummySession.Context()
+
+	// assert
+	assert.Equal(t, dummyContext, result)
+}
+
+func TestSessionGetTrigger_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetTrigger()
+
+	// assert
+	assert.Equal(t, TriggerSourceScheduled, result)
+}
+
+func TestSessionGetTrigger_NilRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetTrigger()
+
+	// assert
+	assert.Equal(t, TriggerSourceScheduled, result)
+}
+
+func TestSessionGetTrigger_ValidRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{
+		round: &round{
+			trigger: TriggerSourceManual,
+		},
+	}
+
+	// act
+	var result = dummySession.GetTrigger()
+
+	// assert
+	assert.Equal(t, TriggerSourceManual, result)
+}
+
+func TestSessionGetUpstreamRounds_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetUpstreamRounds()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetUpstreamRounds_NilRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetUpstreamRounds()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetUpstreamRounds_ValidRound(t *testing.T) {
+	// arrange
+	var dummyUpstream = map[string]*RoundRecord{
+		"some job": {ID: uuid.New()},
+	}
+
+	// SUT
+	var dummySession = &session{
+		round: &round{
+			upstream: dummyUpstream,
+		},
+	}
+
+	// act
+	var result = dummySession.GetUpstreamRounds()
+
+	// assert
+	assert.Equal(t, dummyUpstream, result)
+	delete(result, "some job")
+	assert.Len(t, dummyUpstream, 1)
+}
+
+func TestSessionGetTriggerReason_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetTriggerReason()
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestSessionGetTriggerReason_NilRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetTriggerReason()
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestSessionGetTriggerReason_ValidRound(t *testing.T) {
+	// arrange
+	var dummyReason = "some reason"
+
+	// SUT
+	var dummySession = &session{
+		round: &round{
+			reason: dummyReason,
+		},
+	}
+
+	// act
+	var result = dummySession.GetTriggerReason()
+
+	// assert
+	assert.Equal(t, dummyReason, result)
+}
+
+func TestSessionGetScheduledTime_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetScheduledTime()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetScheduledTime_NilRound(t *testing.T) {
+	// SUT
+	var dummySession = &session{}
+
+	// act
+	var result = dummySession.GetScheduledTime()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetScheduledTime_ValidRound(t *testing.T) {
+	// arrange
+	var dummyScheduled = time.Now().Add(-time.Hour)
+
+	// SUT
+	var dummySession = &session{
+		round: &round{
+			scheduled: dummyScheduled,
+		},
+	}
+
+	// act
+	var result = dummySession.GetScheduledTime()
+
+	// assert
+	assert.Equal(t, dummyScheduled, result)
+}
+
 func TestSessionAttach_NilSessionObject(t *testing.T) {
 	// arrange
 	type dummyAttachment struct {
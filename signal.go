@@ -0,0 +1,99 @@
+package jobrunner
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func trapSignals(app *application) chan os.Signal {
+	if app.host != nil ||
+		!app.customization.HandleSignals() {
+		return nil
+	}
+	var signals = make(chan os.Signal, 1)
+	signal.Notify(
+		signals,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGHUP,
+	)
+	return signals
+}
+
+func reloadApplication(app *application) {
+	var reloadError = app.customization.Reload()
+	if reloadError != nil {
+		logAppRoot(
+			app.session,
+			"signal",
+			"reloadApplication",
+			"Failed to execute customization.Reload. Error: %+v",
+			reloadError,
+		)
+		recordError(
+			app,
+			newRunError(
+				nil,
+				-1,
+				0,
+				RunPhaseReload,
+				reloadError,
+			),
+		)
+		return
+	}
+	logAppRoot(
+		app.session,
+		"signal",
+		"reloadApplication",
+		"customization.Reload executed successfully",
+	)
+}
+
+// processSignal reacts to the received signal and returns whether the application is being stopped
+func processSignal(app *application, received os.Signal, stopping bool) bool {
+	logAppRoot(
+		app.session,
+		"signal",
+		"processSignal",
+		"Received signal [%v]",
+		received,
+	)
+	if received == syscall.SIGHUP {
+		reloadApplication(
+			app,
+		)
+		return stopping
+	}
+	if stopping {
+		// a repeated stop signal means no more patience for the graceful stop
+		app.cancel()
+		return true
+	}
+	go stopGracefully(
+		app,
+		app.customization.GracefulTimeout(),
+	)
+	return true
+}
+
+func handleSignals(app *application, signals chan os.Signal) {
+	if signals == nil {
+		return
+	}
+	defer signal.Stop(signals)
+	var stopping = false
+	for {
+		select {
+		case <-app.terminated:
+			return
+		case received := <-signals:
+			stopping = processSignal(
+				app,
+				received,
+				stopping,
+			)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package jobrunner
+
+import (
+	"errors"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestTrapSignals_Hosted(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		host: &application{},
+	}
+
+	// SUT + act
+	var result = trapSignals(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestTrapSignals_NotEnabled(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		customization: dummyCustomization,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).HandleSignals).Expects(dummyCustomization).Returns(false).Once()
+
+	// SUT + act
+	var result = trapSignals(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestTrapSignals_Enabled(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		customization: dummyCustomization,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).HandleSignals).Expects(dummyCustomization).Returns(true).Once()
+	m.Mock(signal.Notify).Expects(gomocker.Anything(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP).Returns().Once()
+
+	// SUT + act
+	var result = trapSignals(
+		dummyApplication,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+}
+
+func TestReloadApplication_Error(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		errors:        newErrorRing(10),
+		session:       dummySession,
+		customization: dummyCustomization,
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).Reload).Expects(dummyCustomization).Returns(dummyError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "signal", "reloadApplication",
+		"Failed to execute customization.Reload. Error: %+v", dummyError).Returns().Once()
+
+	// SUT + act
+	reloadApplication(
+		dummyApplication,
+	)
+
+	// assert
+	var runErrors = dummyApplication.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, -1, runErrors[0].Index)
+	assert.Equal(t, RunPhaseReload, runErrors[0].Phase)
+	assert.Equal(t, dummyError, runErrors[0].Err)
+}
+
+func TestReloadApplication_Success(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		errors:        newErrorRing(10),
+		session:       dummySession,
+		customization: dummyCustomization,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).Reload).Expects(dummyCustomization).Returns(nil).Once()
+	m.Mock(logAppRoot).Expects(dummySession, "signal", "reloadApplication",
+		"customization.Reload executed successfully").Returns().Once()
+
+	// SUT + act
+	reloadApplication(
+		dummyApplication,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.RunErrors())
+}
+
+func TestProcessSignal_Hangup(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		session: dummySession,
+	}
+	var dummyStopping = rand.IntN(100) > 50
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "signal", "processSignal",
+		"Received signal [%v]", syscall.SIGHUP).Returns().Once()
+	m.Mock(reloadApplication).Expects(dummyApplication).Returns().Once()
+
+	// SUT + act
+	var result = processSignal(
+		dummyApplication,
+		syscall.SIGHUP,
+		dummyStopping,
+	)
+
+	// assert
+	assert.Equal(t, dummyStopping, result)
+}
+
+func TestProcessSignal_FirstStop(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		session:       dummySession,
+		customization: dummyCustomization,
+	}
+	var dummyTimeout = time.Duration(rand.IntN(1000))
+	var dummyStopped = make(chan struct{})
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "signal", "processSignal",
+		"Received signal [%v]", syscall.SIGTERM).Returns().Once()
+	m.Mock((*customization).GracefulTimeout).Expects(dummyCustomization).Returns(dummyTimeout).Once()
+	m.Mock(stopGracefully).Expects(dummyApplication, dummyTimeout).Returns().SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyStopped) })).Once()
+
+	// SUT + act
+	var result = processSignal(
+		dummyApplication,
+		syscall.SIGTERM,
+		false,
+	)
+
+	// assert
+	<-dummyStopped
+	assert.True(t, result)
+}
+
+func TestProcessSignal_RepeatedStop(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyCancelled = false
+	var dummyApplication = &application{
+		session: dummySession,
+		cancel:  func() { dummyCancelled = true },
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "signal", "processSignal",
+		"Received signal [%v]", syscall.SIGINT).Returns().Once()
+
+	// SUT + act
+	var result = processSignal(
+		dummyApplication,
+		syscall.SIGINT,
+		true,
+	)
+
+	// assert
+	assert.True(t, result)
+	assert.True(t, dummyCancelled)
+}
+
+func TestHandleSignals_NilSignals(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+
+	// SUT + act
+	handleSignals(
+		dummyApplication,
+		nil,
+	)
+}
+
+func TestHandleSignals_HappyPath(t *testing.T) {
+	// arrange
+	var dummyTerminated = make(chan struct{})
+	var dummyApplication = &application{
+		terminated: dummyTerminated,
+	}
+	var dummySignals = make(chan os.Signal, 2)
+
+	// stub
+	dummySignals <- syscall.SIGTERM
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(processSignal).Expects(dummyApplication, syscall.SIGTERM, false).Returns(true).SideEffects(
+		gomocker.GeneralSideEffect(1, func() { dummySignals <- syscall.SIGINT })).Once()
+	m.Mock(processSignal).Expects(dummyApplication, syscall.SIGINT, true).Returns(true).SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyTerminated) })).Once()
+	m.Mock(signal.Stop).Expects(gomocker.Anything()).Returns().Once()
+
+	// SUT + act
+	handleSignals(
+		dummyApplication,
+		dummySignals,
+	)
+}
+
+func TestHandleSignals_Integration(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sending signals to own process is not supported on windows")
+	}
+
+	// arrange
+	var dummyTerminated = make(chan struct{})
+	var dummyReloaded = make(chan struct{})
+	type customization struct {
+		DefaultCustomization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		customization: dummyCustomization,
+		terminated:    dummyTerminated,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).HandleSignals).Expects(dummyCustomization).Returns(true).Once()
+	m.Mock((*customization).Reload).Expects(dummyCustomization).Returns(nil).SideEffects(
+		gomocker.GeneralSideEffect(1, func() { close(dummyReloaded) })).Once()
+
+	// SUT
+	var signals = trapSignals(dummyApplication)
+	go handleSignals(dummyApplication, signals)
+
+	// act
+	var process, _ = os.FindProcess(os.Getpid())
+	assert.NoError(t, process.Signal(syscall.SIGHUP))
+
+	// assert
+	select {
+	case <-dummyReloaded:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "SIGHUP was not handled within time limit")
+	}
+	close(dummyTerminated)
+}
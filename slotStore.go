@@ -0,0 +1,117 @@
+package jobrunner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlotStore is the interface for persisting the last completed scheduled slot of each job, e.g. for catching up the slots missed across restarts
+type SlotStore interface {
+	// LoadSlot returns the last completed scheduled slot of the named job, or the zero time if none has been saved
+	LoadSlot(name string) (time.Time, error)
+	// SaveSlot persists the scheduled slot of the named job as its last completed one, unless a later slot has been saved already
+	SaveSlot(name string, slot time.Time) error
+}
+
+type fileSlotStore struct {
+	lock sync.Mutex
+	path string
+}
+
+// NewFileSlotStore creates a slot store keeping the last completed scheduled slot of each job by name as a JSON object in the file at the given path
+func NewFileSlotStore(path string) SlotStore {
+	return &fileSlotStore{
+		path: path,
+	}
+}
+
+// LoadSlot returns the last completed scheduled slot of the named job, or the zero time if none has been saved
+func (store *fileSlotStore) LoadSlot(name string) (time.Time, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	var slots, readError = readSlotFile(
+		store.path,
+	)
+	if readError != nil {
+		return time.Time{}, readError
+	}
+	return slots[name], nil
+}
+
+// SaveSlot persists the scheduled slot of the named job as its last completed one, unless a later slot has been saved already
+func (store *fileSlotStore) SaveSlot(name string, slot time.Time) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	var slots, readError = readSlotFile(
+		store.path,
+	)
+	if readError != nil {
+		return readError
+	}
+	if !slot.After(slots[name]) {
+		return nil
+	}
+	slots[name] = slot.UTC()
+	return writeSlotFile(
+		store.path,
+		slots,
+	)
+}
+
+func readSlotFile(path string) (map[string]time.Time, error) {
+	var slots = map[string]time.Time{}
+	var data, readError = os.ReadFile(
+		path,
+	)
+	if readError != nil {
+		if os.IsNotExist(readError) {
+			return slots, nil
+		}
+		return nil, readError
+	}
+	var unmarshalError = json.Unmarshal(
+		data,
+		&slots,
+	)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf(
+			"Invalid slot file [%v]: %w",
+			path,
+			unmarshalError,
+		)
+	}
+	return slots, nil
+}
+
+// writeSlotFile writes the slots to a temporary file first, and then replaces the slot file at once, so that the slot file is never left partially written
+func writeSlotFile(path string, slots map[string]time.Time) error {
+	var data, marshalError = json.Marshal(
+		slots,
+	)
+	if marshalError != nil {
+		return marshalError
+	}
+	var tempPath = fmt.Sprintf(
+		"%v.%v",
+		path,
+		uuid.New(),
+	)
+	defer os.Remove(tempPath)
+	var writeError = os.WriteFile(
+		tempPath,
+		data,
+		0644,
+	)
+	if writeError != nil {
+		return writeError
+	}
+	return os.Rename(
+		tempPath,
+		path,
+	)
+}
@@ -0,0 +1,182 @@
+package jobrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewFileSlotStore(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+
+	// SUT + act
+	var result = NewFileSlotStore(
+		dummyPath,
+	)
+
+	// assert
+	var store, ok = result.(*fileSlotStore)
+	assert.True(t, ok)
+	assert.Equal(t, dummyPath, store.path)
+}
+
+func TestFileSlotStore_LoadSlot_NotExist(t *testing.T) {
+	// arrange
+	var dummyStore = NewFileSlotStore(filepath.Join(t.TempDir(), "slots.json"))
+
+	// SUT + act
+	var result, err = dummyStore.LoadSlot(
+		"some name",
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Zero(t, result)
+}
+
+func TestFileSlotStore_LoadSlot_Invalid(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "slots.json")
+	assert.NoError(t, os.WriteFile(dummyPath, []byte("some invalid content"), 0644))
+	var dummyStore = NewFileSlotStore(dummyPath)
+
+	// SUT + act
+	var result, err = dummyStore.LoadSlot(
+		"some name",
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.ErrorContains(t, err, "Invalid slot file ["+dummyPath+"]")
+}
+
+func TestFileSlotStore_LoadSlot_ReadError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyError = errors.New("some error")
+	var dummyStore = NewFileSlotStore(dummyPath)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.ReadFile).Expects(dummyPath).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var result, err = dummyStore.LoadSlot(
+		"some name",
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestFileSlotStore_SaveSlot_ReadError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyError = errors.New("some error")
+	var dummyStore = NewFileSlotStore(dummyPath)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(os.ReadFile).Expects(dummyPath).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var err = dummyStore.SaveSlot(
+		"some name",
+		time.Now(),
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestFileSlotStore_SaveSlot_Saved(t *testing.T) {
+	// arrange
+	var dummyDirectory = t.TempDir()
+	var dummyStore = NewFileSlotStore(filepath.Join(dummyDirectory, "slots.json"))
+	var dummySlot1 = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummySlot2 = time.Date(2030, 1, 1, 0, 1, 0, 0, time.UTC)
+
+	// SUT + act
+	var err1 = dummyStore.SaveSlot("some name", dummySlot1)
+	var err2 = dummyStore.SaveSlot("other name", dummySlot2)
+	var err3 = dummyStore.SaveSlot("some name", dummySlot2)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	var result1, _ = dummyStore.LoadSlot("some name")
+	var result2, _ = dummyStore.LoadSlot("other name")
+	assert.True(t, dummySlot2.Equal(result1))
+	assert.True(t, dummySlot2.Equal(result2))
+	var entries, _ = os.ReadDir(dummyDirectory)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileSlotStore_SaveSlot_NotLater(t *testing.T) {
+	// arrange
+	var dummyStore = NewFileSlotStore(filepath.Join(t.TempDir(), "slots.json"))
+	var dummySlot1 = time.Date(2030, 1, 1, 0, 1, 0, 0, time.UTC)
+	var dummySlot2 = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, dummyStore.SaveSlot("some name", dummySlot1))
+
+	// SUT + act
+	var err = dummyStore.SaveSlot(
+		"some name",
+		dummySlot2,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var result, _ = dummyStore.LoadSlot("some name")
+	assert.True(t, dummySlot1.Equal(result))
+}
+
+func TestWriteSlotFile_MarshalError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummySlots = map[string]time.Time{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(json.Marshal).Expects(dummySlots).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var err = writeSlotFile(
+		dummyPath,
+		dummySlots,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteSlotFile_WriteError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "some folder", "slots.json")
+	var dummySlots = map[string]time.Time{}
+
+	// SUT + act
+	var err = writeSlotFile(
+		dummyPath,
+		dummySlots,
+	)
+
+	// assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package jobrunner
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Stage is a named step of the multi-stage pipeline executed by each instance in place of PreAction, ActionFunc and PostAction
+type Stage struct {
+	// Name is the name of the stage, used for logging and reporting its failure
+	Name string
+	// Action is the function executed for the stage
+	Action func(session Session) error
+	// ErrorPolicy is how a failure of the stage is handled, i.e. aborting the pipeline, continuing with the next stage, or aborting the pipeline after compensating the completed stages
+	ErrorPolicy StageErrorPolicy
+	// Compensate is the function undoing the effect of the stage once completed, executed when a later stage fails with the compensate error policy; if nil, nothing is compensated for the stage
+	Compensate func(session Session) error
+}
+
+// runStageStep executes the action or the compensation of a stage, logging its duration and outcome as method enter and exit
+func runStageStep(session *session, category string, name string, step func(session Session) error) error {
+	logMethodEnter(
+		session,
+		category,
+		name,
+		"",
+	)
+	var startTime = time.Now()
+	var err = step(
+		session,
+	)
+	var outcome = "Succeeded"
+	if err != nil {
+		outcome = "Failed"
+	}
+	logMethodExit(
+		session,
+		category,
+		name,
+		"%v after [%s]: %v",
+		outcome,
+		time.Since(startTime),
+		err,
+	)
+	return err
+}
+
+// compensateStages executes the compensations of the completed stages in the reverse order, and returns the failure of the stage joined with any failed compensations
+func compensateStages(session *session, completed []Stage, err error) error {
+	var errs = []error{err}
+	for index := len(completed) - 1; index >= 0; index-- {
+		var stage = completed[index]
+		if stage.Compensate == nil {
+			continue
+		}
+		var compensateError = runStageStep(
+			session,
+			"compensate",
+			stage.Name,
+			stage.Compensate,
+		)
+		if compensateError != nil {
+			errs = append(
+				errs,
+				fmt.Errorf(
+					"Compensation of stage [%v] failed: %w",
+					stage.Name,
+					compensateError,
+				),
+			)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// processStages executes the stages in order, handling the failure of each stage according to its error policy
+func processStages(app *application, session *session, stages []Stage) (RunPhase, error) {
+	var completed = []Stage{}
+	for _, stage := range stages {
+		var stageError = runStageStep(
+			session,
+			"stage",
+			stage.Name,
+			stage.Action,
+		)
+		if stageError == nil {
+			completed = append(
+				completed,
+				stage,
+			)
+			continue
+		}
+		var err = fmt.Errorf(
+			"Stage [%v] failed: %w",
+			stage.Name,
+			stageError,
+		)
+		if stage.ErrorPolicy == StageErrorPolicyContinue {
+			recordError(
+				app,
+				newRunError(
+					session.round,
+					session.index,
+					session.reruns,
+					RunPhaseStage,
+					err,
+				),
+			)
+			continue
+		}
+		if stage.ErrorPolicy == StageErrorPolicyCompensate {
+			return RunPhaseStage, compensateStages(
+				session,
+				completed,
+				err,
+			)
+		}
+		return RunPhaseStage, err
+	}
+	return RunPhaseStage, nil
+}
@@ -0,0 +1,48 @@
+package jobrunner
+
+// StageErrorPolicy is the policy of handling the failure of a stage within the multi-stage pipeline of an instance
+type StageErrorPolicy int
+
+// These are the enum definitions of stage error policies
+const (
+	StageErrorPolicyAbort StageErrorPolicy = iota
+	StageErrorPolicyContinue
+	StageErrorPolicyCompensate
+)
+
+// These are the string representations of stage error policies
+const (
+	abortStageErrorPolicyName      string = "Abort"
+	continueStageErrorPolicyName   string = "Continue"
+	compensateStageErrorPolicyName string = "Compensate"
+)
+
+var supportedStageErrorPolicies = map[StageErrorPolicy]string{
+	StageErrorPolicyAbort:      abortStageErrorPolicyName,
+	StageErrorPolicyContinue:   continueStageErrorPolicyName,
+	StageErrorPolicyCompensate: compensateStageErrorPolicyName,
+}
+
+var stageErrorPolicyNameMapping = map[string]StageErrorPolicy{
+	abortStageErrorPolicyName:      StageErrorPolicyAbort,
+	continueStageErrorPolicyName:   StageErrorPolicyContinue,
+	compensateStageErrorPolicyName: StageErrorPolicyCompensate,
+}
+
+// String converts a StageErrorPolicy instance to its string representation
+func (stageErrorPolicy StageErrorPolicy) String() string {
+	var name, found = supportedStageErrorPolicies[stageErrorPolicy]
+	if !found {
+		return abortStageErrorPolicyName
+	}
+	return name
+}
+
+// NewStageErrorPolicy converts a string representation of StageErrorPolicy to its strongly typed instance
+func NewStageErrorPolicy(value string) StageErrorPolicy {
+	var stageErrorPolicy, found = stageErrorPolicyNameMapping[value]
+	if !found {
+		return StageErrorPolicyAbort
+	}
+	return stageErrorPolicy
+}
@@ -0,0 +1,50 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStageErrorPolicyString_NonSupportedStageErrorPolicy(t *testing.T) {
+	// SUT
+	var sut = StageErrorPolicy(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, abortStageErrorPolicyName, result)
+}
+
+func TestStageErrorPolicyString_SupportedStageErrorPolicy(t *testing.T) {
+	// SUT
+	var sut = StageErrorPolicyCompensate
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, compensateStageErrorPolicyName, result)
+}
+
+func TestNewStageErrorPolicy_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewStageErrorPolicy(dummyValue)
+
+	// assert
+	assert.Equal(t, StageErrorPolicyAbort, result)
+}
+
+func TestNewStageErrorPolicy_HappyPath(t *testing.T) {
+	for key, value := range stageErrorPolicyNameMapping {
+		// SUT + act
+		var result = NewStageErrorPolicy(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
@@ -0,0 +1,338 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestRunStageStep_Succeeded(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyCategory = "some category"
+	var dummyName = "some name"
+	var stepCalled = 0
+	var dummyStep = func(session Session) error {
+		stepCalled++
+		assert.Equal(t, dummySession, session)
+		return nil
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logMethodEnter).Expects(dummySession, dummyCategory, dummyName, "").Returns().Once()
+	m.Mock(logMethodExit).Expects(dummySession, dummyCategory, dummyName, "%v after [%s]: %v",
+		"Succeeded", gomocker.Anything(), nil).Returns().Once()
+
+	// SUT + act
+	var err = runStageStep(
+		dummySession,
+		dummyCategory,
+		dummyName,
+		dummyStep,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stepCalled)
+}
+
+func TestRunStageStep_Failed(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyCategory = "some category"
+	var dummyName = "some name"
+	var dummyError = errors.New("some error")
+	var dummyStep = func(session Session) error {
+		return dummyError
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logMethodEnter).Expects(dummySession, dummyCategory, dummyName, "").Returns().Once()
+	m.Mock(logMethodExit).Expects(dummySession, dummyCategory, dummyName, "%v after [%s]: %v",
+		"Failed", gomocker.Anything(), dummyError).Returns().Once()
+
+	// SUT + act
+	var err = runStageStep(
+		dummySession,
+		dummyCategory,
+		dummyName,
+		dummyStep,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestCompensateStages_NoneCompleted(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyError = errors.New("some error")
+
+	// SUT + act
+	var err = compensateStages(
+		dummySession,
+		[]Stage{},
+		dummyError,
+	)
+
+	// assert
+	assert.ErrorIs(t, err, dummyError)
+	assert.Equal(t, "some error", err.Error())
+}
+
+func TestCompensateStages_ReverseOrder(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyCompensate = func(session Session) error {
+		return nil
+	}
+	var dummyStages = []Stage{
+		{Name: "stage 1", Compensate: dummyCompensate},
+		{Name: "stage 2"},
+		{Name: "stage 3", Compensate: dummyCompensate},
+	}
+	var dummyError = errors.New("some error")
+	var dummyCompensateError = errors.New("some compensate error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runStageStep).Expects(dummySession, "compensate", "stage 3", gomocker.Anything()).Returns(dummyCompensateError).Once()
+	m.Mock(runStageStep).Expects(dummySession, "compensate", "stage 1", gomocker.Anything()).Returns(nil).Once()
+
+	// SUT + act
+	var err = compensateStages(
+		dummySession,
+		dummyStages,
+		dummyError,
+	)
+
+	// assert
+	assert.ErrorIs(t, err, dummyError)
+	assert.ErrorIs(t, err, dummyCompensateError)
+	assert.Equal(t, "some error\nCompensation of stage [stage 3] failed: some compensate error", err.Error())
+}
+
+func TestProcessStages_AllSucceeded(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyStages = []Stage{
+		{Name: "stage 1"},
+		{Name: "stage 2"},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 1", gomocker.Anything()).Returns(nil).Once()
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 2", gomocker.Anything()).Returns(nil).Once()
+
+	// SUT + act
+	var phase, err = processStages(
+		dummyApplication,
+		dummySession,
+		dummyStages,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseStage, phase)
+	assert.NoError(t, err)
+}
+
+func TestProcessStages_Continue(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{id: uuid.New(), index: rand.IntN(100), reruns: rand.IntN(100), round: dummyRound}
+	var dummyStages = []Stage{
+		{Name: "stage 1", ErrorPolicy: StageErrorPolicyContinue},
+		{Name: "stage 2"},
+	}
+	var dummyError = errors.New("some error")
+	var dummyRunError = &RunError{Err: dummyError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 1", gomocker.Anything()).Returns(dummyError).Once()
+	m.Mock(newRunError).Expects(dummyRound, dummySession.index, dummySession.reruns, RunPhaseStage, gomocker.Matches(func(value any) bool {
+		var err, ok = value.(error)
+		return ok &&
+			errors.Is(err, dummyError) &&
+			err.Error() == "Stage [stage 1] failed: some error"
+	})).Returns(dummyRunError).Once()
+	m.Mock(recordError).Expects(dummyApplication, dummyRunError).Returns().Once()
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 2", gomocker.Anything()).Returns(nil).Once()
+
+	// SUT + act
+	var phase, err = processStages(
+		dummyApplication,
+		dummySession,
+		dummyStages,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseStage, phase)
+	assert.NoError(t, err)
+}
+
+func TestProcessStages_Abort(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummyStages = []Stage{
+		{Name: "stage 1"},
+		{Name: "stage 2", ErrorPolicy: StageErrorPolicyAbort},
+		{Name: "stage 3"},
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 1", gomocker.Anything()).Returns(nil).Once()
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 2", gomocker.Anything()).Returns(dummyError).Once()
+
+	// SUT + act
+	var phase, err = processStages(
+		dummyApplication,
+		dummySession,
+		dummyStages,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseStage, phase)
+	assert.ErrorIs(t, err, dummyError)
+	assert.Equal(t, "Stage [stage 2] failed: some error", err.Error())
+}
+
+func TestProcessStages_Compensate(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name: "some name",
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyStages = []Stage{
+		{Name: "stage 1"},
+		{Name: "stage 2", ErrorPolicy: StageErrorPolicyContinue},
+		{Name: "stage 3", ErrorPolicy: StageErrorPolicyCompensate},
+		{Name: "stage 4"},
+	}
+	var dummyError1 = errors.New("some error 1")
+	var dummyError2 = errors.New("some error 2")
+	var dummyResult = errors.New("some result")
+	var dummyRunError = &RunError{Err: dummyError1}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 1", gomocker.Anything()).Returns(nil).Once()
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 2", gomocker.Anything()).Returns(dummyError1).Once()
+	m.Mock(newRunError).Expects(nil, 0, 0, RunPhaseStage, gomocker.Anything()).Returns(dummyRunError).Once()
+	m.Mock(recordError).Expects(dummyApplication, dummyRunError).Returns().Once()
+	m.Mock(runStageStep).Expects(dummySession, "stage", "stage 3", gomocker.Anything()).Returns(dummyError2).Once()
+	m.Mock(compensateStages).Expects(dummySession, []Stage{dummyStages[0]}, gomocker.Matches(func(value any) bool {
+		var err, ok = value.(error)
+		return ok &&
+			!errors.Is(err, dummyError1) &&
+			errors.Is(err, dummyError2) &&
+			err.Error() == "Stage [stage 3] failed: some error 2"
+	})).Returns(dummyResult).Once()
+
+	// SUT + act
+	var phase, err = processStages(
+		dummyApplication,
+		dummySession,
+		dummyStages,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseStage, phase)
+	assert.Equal(t, dummyResult, err)
+}
+
+type toleratedStageCustomization struct {
+	DefaultCustomization
+}
+
+func (customization *toleratedStageCustomization) Log(session Session, logType LogType, logLevel LogLevel, category, subcategory, description string) {
+}
+
+func TestStages_ToleratedFailure(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var attempts = []string{}
+	var dummyCustomization = &toleratedStageCustomization{}
+	var dummyApplication = &application{
+		name:      "some name",
+		instances: 1,
+		stages: []Stage{
+			{
+				Name: "stage 1",
+				Action: func(session Session) error {
+					attempts = append(attempts, "stage 1")
+					return dummyError
+				},
+				ErrorPolicy: StageErrorPolicyContinue,
+			},
+			{
+				Name: "stage 2",
+				Action: func(session Session) error {
+					attempts = append(attempts, "stage 2")
+					return nil
+				},
+			},
+		},
+		retry:         RetryPolicy{MaxAttempts: 3},
+		session:       &session{id: uuid.New(), customization: dummyCustomization},
+		customization: dummyCustomization,
+		errors:        newErrorRing(10),
+		history:       newRoundHistory(10),
+		inflight:      map[uuid.UUID]*session{},
+	}
+	var dummyRound = newRound(TriggerSourceManual, "", time.Now())
+	dummyRound.ctx, dummyRound.cancel = context.WithCancel(context.Background())
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, []string{"stage 1", "stage 2"}, attempts)
+	var records = dummyApplication.History()
+	assert.Len(t, records, 1)
+	assert.False(t, records[0].Failed)
+	assert.Len(t, records[0].Instances, 1)
+	assert.Equal(t, InstanceOutcomeSuccess, records[0].Instances[0].Outcome)
+	assert.Nil(t, records[0].Instances[0].Err)
+	var runErrors = dummyApplication.RunErrors()
+	assert.Len(t, runErrors, 1)
+	assert.Equal(t, RunPhaseStage, runErrors[0].Phase)
+	assert.ErrorIs(t, runErrors[0], dummyError)
+}
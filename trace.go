@@ -0,0 +1,272 @@
+package jobrunner
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpanExporter is the interface for exporting the finished tracing spans of rounds, instances and webcalls, e.g. NewMemorySpanExporter, NewFileSpanExporter, or an adapter to a tracing backend
+type SpanExporter interface {
+	// ExportSpan exports a span as soon as it finishes; spans of the same trace are exported from the innermost to the outermost
+	ExportSpan(span *Span) error
+}
+
+// Span is a finished tracing span of a round, an instance or a webcall
+type Span struct {
+	// TraceID is the W3C trace ID of the span, as 32 lower-case hex digits
+	TraceID string `json:"traceId"`
+	// SpanID is the ID of the span, as 16 lower-case hex digits, propagated as the parent ID in the traceparent header of its webcalls
+	SpanID string `json:"spanId"`
+	// ParentSpanID is the span ID of the parent span, or empty for the root span of a trace
+	ParentSpanID string `json:"parentSpanId,omitempty"`
+	// Name is the name of the span, i.e. round, instance or webcall
+	Name string `json:"name"`
+	// StartTime is the time when the span started
+	StartTime time.Time `json:"startTime"`
+	// EndTime is the time when the span finished
+	EndTime time.Time `json:"endTime"`
+	// Attributes holds the attributes of the span, e.g. app.name, instance.index, http.status_code, etc.
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Error is the error message of the span, or empty if the span succeeded
+	Error string `json:"error,omitempty"`
+
+	exporter SpanExporter
+}
+
+func newTraceID() string {
+	var id = uuid.New()
+	return hex.EncodeToString(id[:])
+}
+
+func newSpanID() string {
+	var id = uuid.New()
+	return hex.EncodeToString(id[:8])
+}
+
+// startSpan starts a span as a child of the given parent span, or as the root span of a new trace if the parent is nil; returns nil if the exporter is nil, i.e. tracing is disabled
+func startSpan(exporter SpanExporter, parent *Span, name string, attributes map[string]any) *Span {
+	if isInterfaceValueNil(exporter) {
+		return nil
+	}
+	var span = &Span{
+		TraceID:    newTraceID(),
+		SpanID:     newSpanID(),
+		Name:       name,
+		StartTime:  time.Now().UTC(),
+		Attributes: attributes,
+		exporter:   exporter,
+	}
+	if parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+	}
+	return span
+}
+
+// endSpan finishes the span with the given attributes and error, and exports it; it does nothing if the span is nil, i.e. tracing is disabled
+func endSpan(session *session, span *Span, attributes map[string]any, err error) {
+	if span == nil {
+		return
+	}
+	span.EndTime = time.Now().UTC()
+	for key, value := range attributes {
+		span.Attributes[key] = value
+	}
+	if err != nil {
+		span.Error = err.Error()
+	}
+	var exportError = span.exporter.ExportSpan(
+		span,
+	)
+	if exportError != nil {
+		logAppRoot(
+			session,
+			"trace",
+			"endSpan",
+			"Failed to export span [%v] of trace [%v]. Error: %+v",
+			span.SpanID,
+			span.TraceID,
+			exportError,
+		)
+	}
+}
+
+// formatTraceparent returns the W3C traceparent header value carrying the trace context of the span, always flagged as sampled
+func formatTraceparent(span *Span) string {
+	return "00-" + span.TraceID + "-" + span.SpanID + "-01"
+}
+
+func startRoundSpan(app *application, round *round) *Span {
+	return startSpan(
+		app.exporter,
+		nil,
+		"round",
+		map[string]any{
+			"app.name":      app.name,
+			"app.version":   app.version,
+			"round.id":      round.id.String(),
+			"round.trigger": round.trigger.String(),
+		},
+	)
+}
+
+func endRoundSpan(app *application, round *round, record *RoundRecord) {
+	endSpan(
+		app.session,
+		round.span,
+		map[string]any{
+			"round.status":    record.Status.String(),
+			"round.failed":    record.Failed,
+			"round.instances": len(record.Instances),
+		},
+		nil,
+	)
+}
+
+func startInstanceSpan(app *application, session *session) *Span {
+	return startSpan(
+		app.exporter,
+		session.round.span,
+		"instance",
+		map[string]any{
+			"app.name":        app.name,
+			"app.version":     app.version,
+			"instance.index":  session.index,
+			"instance.reruns": session.reruns,
+		},
+	)
+}
+
+func endInstanceSpan(session *session, record *InstanceRecord, err error) {
+	endSpan(
+		session,
+		session.span,
+		map[string]any{
+			"instance.outcome": record.Outcome.String(),
+		},
+		err,
+	)
+}
+
+// startWebcallSpan starts a span for the webcall as a child of the span of its session; returns nil if the session is not traced
+func startWebcallSpan(webRequest *webRequest) *Span {
+	var parent = webRequest.session.span
+	if parent == nil {
+		return nil
+	}
+	return startSpan(
+		parent.exporter,
+		parent,
+		"webcall",
+		map[string]any{
+			"http.method": webRequest.method,
+			"http.url":    webRequest.url,
+		},
+	)
+}
+
+func endWebcallSpan(webRequest *webRequest, statusCode int, responseError error) {
+	endSpan(
+		webRequest.session,
+		webRequest.span,
+		map[string]any{
+			"http.status_code": statusCode,
+		},
+		responseError,
+	)
+}
+
+type memorySpanExporter struct {
+	lock  sync.Mutex
+	spans []*Span
+}
+
+// MemorySpanExporter is a span exporter keeping all exported spans in memory, e.g. for tests or local troubleshooting
+type MemorySpanExporter interface {
+	SpanExporter
+	// Spans returns the exported spans in the order of their export
+	Spans() []*Span
+	// Reset discards all exported spans
+	Reset()
+}
+
+// NewMemorySpanExporter creates a span exporter keeping all exported spans in memory
+func NewMemorySpanExporter() MemorySpanExporter {
+	return &memorySpanExporter{
+		spans: []*Span{},
+	}
+}
+
+// ExportSpan keeps the span in memory
+func (exporter *memorySpanExporter) ExportSpan(span *Span) error {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	exporter.spans = append(
+		exporter.spans,
+		span,
+	)
+	return nil
+}
+
+// Spans returns the exported spans in the order of their export
+func (exporter *memorySpanExporter) Spans() []*Span {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	return append(
+		[]*Span{},
+		exporter.spans...,
+	)
+}
+
+// Reset discards all exported spans
+func (exporter *memorySpanExporter) Reset() {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	exporter.spans = []*Span{}
+}
+
+type fileSpanExporter struct {
+	lock sync.Mutex
+	path string
+}
+
+// NewFileSpanExporter creates a span exporter appending spans as JSON lines to the file at the given path, e.g. for local troubleshooting
+func NewFileSpanExporter(path string) SpanExporter {
+	return &fileSpanExporter{
+		path: path,
+	}
+}
+
+// ExportSpan appends the span as a JSON line to the file
+func (exporter *fileSpanExporter) ExportSpan(span *Span) error {
+	var content, marshalError = json.Marshal(
+		span,
+	)
+	if marshalError != nil {
+		return marshalError
+	}
+	content = append(
+		content,
+		'\n',
+	)
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	var file, openError = os.OpenFile(
+		exporter.path,
+		os.O_CREATE|os.O_APPEND|os.O_WRONLY,
+		0644,
+	)
+	if openError != nil {
+		return openError
+	}
+	defer file.Close()
+	var _, writeError = file.Write(
+		content,
+	)
+	return writeError
+}
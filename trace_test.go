@@ -0,0 +1,619 @@
+package jobrunner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestNewTraceID(t *testing.T) {
+	// SUT + act
+	var result1 = newTraceID()
+	var result2 = newTraceID()
+
+	// assert
+	assert.Regexp(t, "^[0-9a-f]{32}$", result1)
+	assert.Regexp(t, "^[0-9a-f]{32}$", result2)
+	assert.NotEqual(t, result1, result2)
+}
+
+func TestNewSpanID(t *testing.T) {
+	// SUT + act
+	var result1 = newSpanID()
+	var result2 = newSpanID()
+
+	// assert
+	assert.Regexp(t, "^[0-9a-f]{16}$", result1)
+	assert.Regexp(t, "^[0-9a-f]{16}$", result2)
+	assert.NotEqual(t, result1, result2)
+}
+
+func TestStartSpan_NoExporter(t *testing.T) {
+	// SUT + act
+	var result = startSpan(
+		nil,
+		nil,
+		"some name",
+		map[string]any{},
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestStartSpan_TypedNilExporter(t *testing.T) {
+	// arrange
+	var dummyExporter *fileSpanExporter
+
+	// SUT + act
+	var result = startSpan(
+		dummyExporter,
+		nil,
+		"some name",
+		map[string]any{},
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestStartSpan_Root(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyAttributes = map[string]any{"foo": "bar"}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newTraceID).Expects().Returns("some trace").Once()
+	m.Mock(newSpanID).Expects().Returns("some span").Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+
+	// SUT + act
+	var result = startSpan(
+		dummyExporter,
+		nil,
+		"some name",
+		dummyAttributes,
+	)
+
+	// assert
+	assert.Equal(t, &Span{
+		TraceID:    "some trace",
+		SpanID:     "some span",
+		Name:       "some name",
+		StartTime:  dummyTimeNow,
+		Attributes: dummyAttributes,
+		exporter:   dummyExporter,
+	}, result)
+}
+
+func TestStartSpan_Child(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyParent = &Span{TraceID: "parent trace", SpanID: "parent span"}
+
+	// SUT + act
+	var result = startSpan(
+		dummyExporter,
+		dummyParent,
+		"some name",
+		map[string]any{},
+	)
+
+	// assert
+	assert.Equal(t, "parent trace", result.TraceID)
+	assert.Equal(t, "parent span", result.ParentSpanID)
+	assert.Regexp(t, "^[0-9a-f]{16}$", result.SpanID)
+}
+
+func TestEndSpan_NilSpan(t *testing.T) {
+	// SUT + act
+	endSpan(
+		&session{id: uuid.New()},
+		nil,
+		map[string]any{},
+		errors.New("some error"),
+	)
+}
+
+func TestEndSpan_Success(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummySpan = &Span{
+		Attributes: map[string]any{"foo": "bar"},
+		exporter:   dummyExporter,
+	}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+
+	// SUT + act
+	endSpan(
+		&session{id: uuid.New()},
+		dummySpan,
+		map[string]any{"test": 123},
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyTimeNow, dummySpan.EndTime)
+	assert.Equal(t, map[string]any{"foo": "bar", "test": 123}, dummySpan.Attributes)
+	assert.Empty(t, dummySpan.Error)
+	assert.Equal(t, []*Span{dummySpan}, dummyExporter.Spans())
+}
+
+type failingSpanExporter struct{}
+
+func (exporter *failingSpanExporter) ExportSpan(span *Span) error {
+	return errors.New("some export error")
+}
+
+func TestEndSpan_ExportError(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummySpan = &Span{
+		TraceID:    "some trace",
+		SpanID:     "some span",
+		Attributes: map[string]any{},
+		exporter:   &failingSpanExporter{},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, "trace", "endSpan",
+		"Failed to export span [%v] of trace [%v]. Error: %+v", "some span", "some trace", gomocker.Anything()).Returns().Once()
+
+	// SUT + act
+	endSpan(
+		dummySession,
+		dummySpan,
+		nil,
+		errors.New("some error"),
+	)
+
+	// assert
+	assert.Equal(t, "some error", dummySpan.Error)
+}
+
+func TestFormatTraceparent(t *testing.T) {
+	// arrange
+	var dummySpan = &Span{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	}
+
+	// SUT + act
+	var result = formatTraceparent(
+		dummySpan,
+	)
+
+	// assert
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", result)
+}
+
+func TestStartRoundSpan(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyApplication = &application{
+		name:     "some name",
+		version:  "some version",
+		exporter: dummyExporter,
+	}
+	var dummyRound = &round{id: uuid.New(), trigger: TriggerSourceManual}
+	var dummySpan = &Span{SpanID: "some span"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startSpan).Expects(dummyExporter, (*Span)(nil), "round", map[string]any{
+		"app.name":      "some name",
+		"app.version":   "some version",
+		"round.id":      dummyRound.id.String(),
+		"round.trigger": "Manual",
+	}).Returns(dummySpan).Once()
+
+	// SUT + act
+	var result = startRoundSpan(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, result)
+}
+
+func TestEndRoundSpan(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyApplication = &application{session: dummySession}
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyRound = &round{span: dummySpan}
+	var dummyRecord = &RoundRecord{
+		Status:    RoundStatusCompleted,
+		Failed:    true,
+		Instances: []*InstanceRecord{{}, {}},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(endSpan).Expects(dummySession, dummySpan, map[string]any{
+		"round.status":    "Completed",
+		"round.failed":    true,
+		"round.instances": 2,
+	}, nil).Returns().Once()
+
+	// SUT + act
+	endRoundSpan(
+		dummyApplication,
+		dummyRound,
+		dummyRecord,
+	)
+}
+
+func TestStartInstanceSpan(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyApplication = &application{
+		name:     "some name",
+		version:  "some version",
+		exporter: dummyExporter,
+	}
+	var dummyParent = &Span{SpanID: "parent span"}
+	var dummySession = &session{
+		index:  rand.Int(),
+		reruns: rand.Int(),
+		round:  &round{span: dummyParent},
+	}
+	var dummySpan = &Span{SpanID: "some span"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startSpan).Expects(dummyExporter, dummyParent, "instance", map[string]any{
+		"app.name":        "some name",
+		"app.version":     "some version",
+		"instance.index":  dummySession.index,
+		"instance.reruns": dummySession.reruns,
+	}).Returns(dummySpan).Once()
+
+	// SUT + act
+	var result = startInstanceSpan(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, result)
+}
+
+func TestEndInstanceSpan(t *testing.T) {
+	// arrange
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummySession = &session{id: uuid.New(), span: dummySpan}
+	var dummyRecord = &InstanceRecord{Outcome: InstanceOutcomeTimeout}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(endSpan).Expects(dummySession, dummySpan, map[string]any{
+		"instance.outcome": "Timeout",
+	}, dummyError).Returns().Once()
+
+	// SUT + act
+	endInstanceSpan(
+		dummySession,
+		dummyRecord,
+		dummyError,
+	)
+}
+
+func TestStartWebcallSpan_NotTraced(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		session: &session{id: uuid.New()},
+	}
+
+	// SUT + act
+	var result = startWebcallSpan(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestStartWebcallSpan_Traced(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyParent = &Span{SpanID: "parent span", exporter: dummyExporter}
+	var dummyWebRequest = &webRequest{
+		session: &session{id: uuid.New(), span: dummyParent},
+		method:  "some method",
+		url:     "some URL",
+	}
+	var dummySpan = &Span{SpanID: "some span"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(startSpan).Expects(dummyExporter, dummyParent, "webcall", map[string]any{
+		"http.method": "some method",
+		"http.url":    "some URL",
+	}).Returns(dummySpan).Once()
+
+	// SUT + act
+	var result = startWebcallSpan(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, result)
+}
+
+func TestEndWebcallSpan(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyWebRequest = &webRequest{
+		session: dummySession,
+		span:    dummySpan,
+	}
+	var dummyStatusCode = rand.Int()
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(endSpan).Expects(dummySession, dummySpan, map[string]any{
+		"http.status_code": dummyStatusCode,
+	}, dummyError).Returns().Once()
+
+	// SUT + act
+	endWebcallSpan(
+		dummyWebRequest,
+		dummyStatusCode,
+		dummyError,
+	)
+}
+
+func TestMemorySpanExporter(t *testing.T) {
+	// arrange
+	var dummySpan1 = &Span{SpanID: "some span"}
+	var dummySpan2 = &Span{SpanID: "other span"}
+
+	// SUT
+	var sut = NewMemorySpanExporter()
+
+	// act
+	var err1 = sut.ExportSpan(dummySpan1)
+	var err2 = sut.ExportSpan(dummySpan2)
+	var result = sut.Spans()
+	sut.Reset()
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, []*Span{dummySpan1, dummySpan2}, result)
+	assert.Empty(t, sut.Spans())
+}
+
+func TestNewFileSpanExporter(t *testing.T) {
+	// SUT + act
+	var result = NewFileSpanExporter(
+		"some path",
+	)
+
+	// assert
+	assert.Equal(t, &fileSpanExporter{path: "some path"}, result)
+}
+
+func TestFileSpanExporterExportSpan_MarshalError(t *testing.T) {
+	// arrange
+	var dummySpan = &Span{SpanID: "some span"}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(json.Marshal).Expects(dummySpan).Returns(nil, dummyError).Once()
+
+	// SUT
+	var sut = NewFileSpanExporter("some path")
+
+	// act
+	var err = sut.ExportSpan(
+		dummySpan,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestFileSpanExporterExportSpan_OpenError(t *testing.T) {
+	// SUT
+	var sut = NewFileSpanExporter(
+		filepath.Join(t.TempDir(), "some folder", "spans.jsonl"),
+	)
+
+	// act
+	var err = sut.ExportSpan(
+		&Span{SpanID: "some span"},
+	)
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestFileSpanExporterExportSpan_Success(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "spans.jsonl")
+	var dummySpan = &Span{
+		TraceID:      "some trace",
+		SpanID:       "some span",
+		ParentSpanID: "parent span",
+		Name:         "webcall",
+		StartTime:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:      time.Date(2021, 1, 1, 0, 0, 1, 0, time.UTC),
+		Attributes:   map[string]any{"http.status_code": 200},
+	}
+
+	// SUT
+	var sut = NewFileSpanExporter(dummyPath)
+
+	// act
+	var err1 = sut.ExportSpan(dummySpan)
+	var err2 = sut.ExportSpan(dummySpan)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	var content, _ = os.ReadFile(dummyPath)
+	var line = `{"traceId":"some trace","spanId":"some span","parentSpanId":"parent span","name":"webcall","startTime":"2021-01-01T00:00:00Z","endTime":"2021-01-01T00:00:01Z","attributes":{"http.status_code":200}}` + "\n"
+	assert.Equal(t, line+line, string(content))
+}
+
+func TestTracing_Hierarchy(t *testing.T) {
+	// arrange
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyApplication = &application{
+		name:     "some name",
+		exporter: dummyExporter,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{id: uuid.New(), round: dummyRound}
+	var dummyWebRequest = &webRequest{session: dummySession}
+
+	// SUT + act
+	dummyRound.span = startRoundSpan(dummyApplication, dummyRound)
+	dummySession.span = startInstanceSpan(dummyApplication, dummySession)
+	dummyWebRequest.span = startWebcallSpan(dummyWebRequest)
+	endWebcallSpan(dummyWebRequest, 200, nil)
+	endInstanceSpan(dummySession, &InstanceRecord{Outcome: InstanceOutcomeSuccess}, nil)
+	endRoundSpan(dummyApplication, dummyRound, &RoundRecord{Status: RoundStatusCompleted})
+
+	// assert
+	var spans = dummyExporter.Spans()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, []string{"webcall", "instance", "round"}, []string{spans[0].Name, spans[1].Name, spans[2].Name})
+	assert.Equal(t, spans[2].TraceID, spans[0].TraceID)
+	assert.Equal(t, spans[2].TraceID, spans[1].TraceID)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, spans[2].SpanID, spans[1].ParentSpanID)
+	assert.Empty(t, spans[2].ParentSpanID)
+	assert.True(t, strings.HasPrefix(formatTraceparent(spans[0]), "00-"+spans[2].TraceID+"-"))
+}
+
+type tracedWorkCustomization struct {
+	DefaultCustomization
+	url string
+}
+
+func (customization *tracedWorkCustomization) Log(session Session, logType LogType, logLevel LogLevel, category, subcategory, description string) {
+}
+
+func (customization *tracedWorkCustomization) ProduceWork(session Session) (iter.Seq[any], error) {
+	var _, _, err = session.CreateWebcallRequest(http.MethodGet, customization.url+"/produce", "", false).Process()
+	return slices.Values([]any{"some item", "other item"}), err
+}
+
+func (customization *tracedWorkCustomization) ConsumeWork(session Session, item any) error {
+	var _, _, err = session.CreateWebcallRequest(http.MethodGet, customization.url+"/consume", "", false).Process()
+	return err
+}
+
+func TestTracing_WorkQueue(t *testing.T) {
+	// arrange
+	var lock sync.Mutex
+	var traceparents = map[string][]string{}
+	var dummyServer = httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		traceparents[request.URL.Path] = append(traceparents[request.URL.Path], request.Header.Get("traceparent"))
+	}))
+	defer dummyServer.Close()
+	var dummyExporter = NewMemorySpanExporter()
+	var dummyCustomization = &tracedWorkCustomization{url: dummyServer.URL}
+	var dummyApplication = &application{
+		name:          "some name",
+		instances:     1,
+		workQueue:     true,
+		exporter:      dummyExporter,
+		session:       &session{id: uuid.New(), customization: dummyCustomization},
+		customization: dummyCustomization,
+		clients:       initializeHTTPClients(time.Minute, false, nil, dummyCustomization.RoundTripper),
+		errors:        newErrorRing(10),
+		history:       newRoundHistory(10),
+		inflight:      map[uuid.UUID]*session{},
+	}
+	var dummyRound = newRound(TriggerSourceManual, "", time.Now())
+	dummyRound.ctx, dummyRound.cancel = context.WithCancel(context.Background())
+
+	// SUT + act
+	runInstances(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Empty(t, dummyApplication.RunErrors())
+	var spans = map[string]*Span{}
+	var webcalls = map[string]*Span{}
+	for _, span := range dummyExporter.Spans() {
+		if span.Name == "webcall" {
+			webcalls[span.SpanID] = span
+		} else {
+			spans[span.Name] = span
+		}
+	}
+	assert.Len(t, webcalls, 3)
+	assert.Len(t, traceparents["/produce"], 1)
+	assert.Len(t, traceparents["/consume"], 2)
+	var parents = map[string]string{}
+	for _, path := range []string{"/produce", "/consume"} {
+		for _, traceparent := range traceparents[path] {
+			var parts = strings.Split(traceparent, "-")
+			assert.Len(t, parts, 4)
+			assert.Equal(t, spans["round"].TraceID, parts[1])
+			var webcall = webcalls[parts[2]]
+			assert.NotNil(t, webcall)
+			parents[webcall.ParentSpanID] = path
+		}
+	}
+	assert.Equal(t, map[string]string{
+		spans["round"].SpanID:    "/produce",
+		spans["instance"].SpanID: "/consume",
+	}, parents)
+}
@@ -0,0 +1,63 @@
+package jobrunner
+
+// TriggerSource is the source which triggers a round of execution
+type TriggerSource int
+
+// These are the enum definitions of trigger sources
+const (
+	TriggerSourceScheduled TriggerSource = iota
+	TriggerSourceManual
+	TriggerSourceUpstream
+	TriggerSourceCatchUp
+)
+
+// These are the string representations of trigger sources
+const (
+	scheduledTriggerSourceName string = "Scheduled"
+	manualTriggerSourceName    string = "Manual"
+	upstreamTriggerSourceName  string = "Upstream"
+	catchUpTriggerSourceName   string = "CatchUp"
+)
+
+var supportedTriggerSources = map[TriggerSource]string{
+	TriggerSourceScheduled: scheduledTriggerSourceName,
+	TriggerSourceManual:    manualTriggerSourceName,
+	TriggerSourceUpstream:  upstreamTriggerSourceName,
+	TriggerSourceCatchUp:   catchUpTriggerSourceName,
+}
+
+var triggerSourceNameMapping = map[string]TriggerSource{
+	scheduledTriggerSourceName: TriggerSourceScheduled,
+	manualTriggerSourceName:    TriggerSourceManual,
+	upstreamTriggerSourceName:  TriggerSourceUpstream,
+	catchUpTriggerSourceName:   TriggerSourceCatchUp,
+}
+
+// String converts a TriggerSource instance to its string representation
+func (triggerSource TriggerSource) String() string {
+	var name, found = supportedTriggerSources[triggerSource]
+	if !found {
+		return scheduledTriggerSourceName
+	}
+	return name
+}
+
+// NewTriggerSource converts a string representation of TriggerSource to its strongly typed instance
+func NewTriggerSource(value string) TriggerSource {
+	var triggerSource, found = triggerSourceNameMapping[value]
+	if !found {
+		return TriggerSourceScheduled
+	}
+	return triggerSource
+}
+
+// MarshalText converts a TriggerSource instance to its string representation for encodings like JSON
+func (triggerSource TriggerSource) MarshalText() ([]byte, error) {
+	return []byte(triggerSource.String()), nil
+}
+
+// UnmarshalText converts a string representation of TriggerSource from encodings like JSON to its strongly typed instance
+func (triggerSource *TriggerSource) UnmarshalText(text []byte) error {
+	*triggerSource = NewTriggerSource(string(text))
+	return nil
+}
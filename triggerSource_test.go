@@ -0,0 +1,74 @@
+package jobrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerSourceString_NonSupportedTriggerSource(t *testing.T) {
+	// SUT
+	var sut = TriggerSource(-1)
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, scheduledTriggerSourceName, result)
+}
+
+func TestTriggerSourceString_SupportedTriggerSource(t *testing.T) {
+	// SUT
+	var sut = TriggerSourceManual
+
+	// act
+	var result = sut.String()
+
+	// assert
+	assert.Equal(t, manualTriggerSourceName, result)
+}
+
+func TestNewTriggerSource_NoMatchFound(t *testing.T) {
+	// arrange
+	var dummyValue = "some value"
+
+	// SUT + act
+	var result = NewTriggerSource(dummyValue)
+
+	// assert
+	assert.Equal(t, TriggerSourceScheduled, result)
+}
+
+func TestNewTriggerSource_HappyPath(t *testing.T) {
+	for key, value := range triggerSourceNameMapping {
+		// SUT + act
+		var result = NewTriggerSource(key)
+
+		// assert
+		assert.Equal(t, value, result)
+	}
+}
+
+func TestTriggerSourceMarshalText(t *testing.T) {
+	// SUT
+	var sut = TriggerSourceManual
+
+	// act
+	var result, err = sut.MarshalText()
+
+	// assert
+	assert.Equal(t, []byte("Manual"), result)
+	assert.NoError(t, err)
+}
+
+func TestTriggerSourceUnmarshalText(t *testing.T) {
+	// SUT
+	var sut TriggerSource
+
+	// act
+	var err = sut.UnmarshalText([]byte("Manual"))
+
+	// assert
+	assert.Equal(t, TriggerSourceManual, sut)
+	assert.NoError(t, err)
+}
@@ -12,16 +12,20 @@ import (
 	"time"
 )
 
-var (
-	httpClientWithCert *http.Client
-	httpClientNoCert   *http.Client
-)
+// httpClients holds the HTTP clients of an application for its webcall requests, initialized upon bootstrapping and shared by all jobs hosted by the same Runner
+type httpClients struct {
+	withCert *http.Client
+	noCert   *http.Client
+}
 
-func getClientForRequest(sendClientCert bool) *http.Client {
+func getClientForRequest(clients *httpClients, sendClientCert bool) *http.Client {
+	if clients == nil {
+		return http.DefaultClient
+	}
 	if sendClientCert {
-		return httpClientWithCert
+		return clients.withCert
 	}
-	return httpClientNoCert
+	return clients.noCert
 }
 
 func clientDoWithRetry(
@@ -37,6 +41,9 @@ func clientDoWithRetry(
 		responseObject, responseError = httpClient.Do(
 			httpRequest,
 		)
+		if httpRequest.Context().Err() != nil {
+			break
+		}
 		if responseError != nil {
 			if connectivityRetryCount <= 0 {
 				break
@@ -51,13 +58,30 @@ func clientDoWithRetry(
 		} else {
 			break
 		}
-		time.Sleep(
+		if !waitRetryDelay(
+			httpRequest,
 			retryDelay,
-		)
+		) {
+			break
+		}
 	}
 	return responseObject, responseError
 }
 
+// waitRetryDelay waits for the delay before retrying the webcall, or returns false as soon as the request is cancelled, e.g. upon stopping the application
+func waitRetryDelay(httpRequest *http.Request, retryDelay time.Duration) bool {
+	var timer = time.NewTimer(
+		retryDelay,
+	)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-httpRequest.Context().Done():
+		return false
+	}
+}
+
 func getHTTPTransport(
 	skipServerCertVerification bool,
 	clientCertificate *tls.Certificate,
@@ -86,14 +110,16 @@ func initializeHTTPClients(
 	skipServerCertVerification bool,
 	clientCertificate *tls.Certificate,
 	roundTripperWrapper func(originalTransport http.RoundTripper) http.RoundTripper,
-) {
-	httpClientWithCert = &http.Client{
-		Transport: getHTTPTransport(skipServerCertVerification, clientCertificate, roundTripperWrapper),
-		Timeout:   webcallTimeout,
-	}
-	httpClientNoCert = &http.Client{
-		Transport: getHTTPTransport(skipServerCertVerification, nil, roundTripperWrapper),
-		Timeout:   webcallTimeout,
+) *httpClients {
+	return &httpClients{
+		withCert: &http.Client{
+			Transport: getHTTPTransport(skipServerCertVerification, clientCertificate, roundTripperWrapper),
+			Timeout:   webcallTimeout,
+		},
+		noCert: &http.Client{
+			Transport: getHTTPTransport(skipServerCertVerification, nil, roundTripperWrapper),
+			Timeout:   webcallTimeout,
+		},
 	}
 }
 
@@ -129,6 +155,7 @@ type webRequest struct {
 	sendClientCert bool
 	retryDelay     time.Duration
 	dataReceivers  []dataReceiver
+	span           *Span
 }
 
 // AddQuery adds a query to the request URL for sending through HTTP
@@ -237,7 +264,8 @@ func createHTTPRequest(webRequest *webRequest) (*http.Request, error) {
 	var requestBody = strings.NewReader(
 		webRequest.payload,
 	)
-	var requestObject, requestError = http.NewRequest(
+	var requestObject, requestError = http.NewRequestWithContext(
+		webRequest.session.Context(),
 		webRequest.method,
 		requestURL,
 		requestBody,
@@ -263,6 +291,14 @@ func createHTTPRequest(webRequest *webRequest) (*http.Request, error) {
 			requestObject.Header.Add(name, value)
 		}
 	}
+	if webRequest.span != nil {
+		requestObject.Header.Set(
+			"traceparent",
+			formatTraceparent(
+				webRequest.span,
+			),
+		)
+	}
 	logWebcallRequest(
 		webRequest.session,
 		"Header",
@@ -344,6 +380,7 @@ func doRequestProcessing(webRequest *webRequest) (*http.Response, error) {
 		return nil, requestError
 	}
 	var httpClient = getClientForRequest(
+		webRequest.session.clients,
 		webRequest.sendClientCert,
 	)
 	var startTime = time.Now().UTC()
@@ -354,6 +391,12 @@ func doRequestProcessing(webRequest *webRequest) (*http.Response, error) {
 		webRequest.httpRetry,
 		webRequest.retryDelay,
 	)
+	observeWebcall(
+		requestObject,
+		responseObject,
+		responseError,
+		startTime,
+	)
 	if responseError != nil {
 		logErrorResponse(
 			webRequest.session,
@@ -420,6 +463,16 @@ func (webRequest *webRequest) Process() (statusCode int, responseHeader http.Hea
 		webRequest.session == nil {
 		return 0, nil, fmt.Errorf("WebRequest is nil or contains invalid session")
 	}
+	webRequest.span = startWebcallSpan(
+		webRequest,
+	)
+	defer func() {
+		endWebcallSpan(
+			webRequest,
+			statusCode,
+			responseError,
+		)
+	}()
 	var responseObject *http.Response
 	responseObject, responseError = doRequestProcessing(
 		webRequest,
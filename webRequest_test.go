@@ -2,6 +2,7 @@ package jobrunner
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -18,17 +19,25 @@ import (
 	"github.com/zhongjie-cai/gomocker/v2"
 )
 
+func TestGetClientForRequest_NilClients(t *testing.T) {
+	// SUT + act
+	var result = getClientForRequest(nil, rand.IntN(100) < 50)
+
+	// assert
+	assert.Equal(t, http.DefaultClient, result)
+}
+
 func TestGetClientForRequest_SendClientCert(t *testing.T) {
 	// arrange
 	var dummyHTTPClient1 = &http.Client{Timeout: time.Duration(rand.Int())}
 	var dummyHTTPClient2 = &http.Client{Timeout: time.Duration(rand.Int())}
-
-	// stub
-	httpClientWithCert = dummyHTTPClient1
-	httpClientNoCert = dummyHTTPClient2
+	var dummyClients = &httpClients{
+		withCert: dummyHTTPClient1,
+		noCert:   dummyHTTPClient2,
+	}
 
 	// SUT + act
-	var result = getClientForRequest(true)
+	var result = getClientForRequest(dummyClients, true)
 
 	// assert
 	assert.Equal(t, dummyHTTPClient1, result)
@@ -38,13 +47,13 @@ func TestGetClientForRequest_NoSendClientCert(t *testing.T) {
 	// arrange
 	var dummyHTTPClient1 = &http.Client{Timeout: time.Duration(rand.Int())}
 	var dummyHTTPClient2 = &http.Client{Timeout: time.Duration(rand.Int())}
-
-	// stub
-	httpClientWithCert = dummyHTTPClient1
-	httpClientNoCert = dummyHTTPClient2
+	var dummyClients = &httpClients{
+		withCert: dummyHTTPClient1,
+		noCert:   dummyHTTPClient2,
+	}
 
 	// SUT + act
-	var result = getClientForRequest(false)
+	var result = getClientForRequest(dummyClients, false)
 
 	// assert
 	assert.Equal(t, dummyHTTPClient2, result)
@@ -80,6 +89,40 @@ func TestClientDoWithRetry_ConnError_NoRetry(t *testing.T) {
 	assert.Equal(t, dummyResponseError, err)
 }
 
+func TestClientDoWithRetry_ContextCancelled(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = (&http.Request{}).WithContext(dummyContext)
+	var dummyConnRetry = 2
+	var dummyHTTPRetry = map[int]int{}
+	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummyResponseObject *http.Response
+	var dummyResponseError = errors.New("some error")
+
+	// stub
+	dummyCancel()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryDelay,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
+}
+
 func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
 	// arrange
 	var dummyClient = &http.Client{}
@@ -96,7 +139,7 @@ func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
 	// expect
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Once()
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Once()
+	m.Mock(waitRetryDelay).Expects(dummyRequestObject, dummyRetryDelay).Returns(true).Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
@@ -127,7 +170,7 @@ func TestClientDoWithRetry_ConnError_RetryFail(t *testing.T) {
 
 	// expect
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Times(3)
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Twice()
+	m.Mock(waitRetryDelay).Expects(dummyRequestObject, dummyRetryDelay).Returns(true).Twice()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
@@ -222,7 +265,7 @@ func TestClientDoWithRetry_HTTPError_RetryOK(t *testing.T) {
 	// expect
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject1, nil).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject2, nil).Once()
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Once()
+	m.Mock(waitRetryDelay).Expects(dummyRequestObject, dummyRetryDelay).Returns(true).Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
@@ -257,7 +300,7 @@ func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
 
 	// expect
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Times(3)
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Twice()
+	m.Mock(waitRetryDelay).Expects(dummyRequestObject, dummyRetryDelay).Returns(true).Twice()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
@@ -273,6 +316,68 @@ func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClientDoWithRetry_RetryCancelled(t *testing.T) {
+	// arrange
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyConnRetry = 2
+	var dummyHTTPRetry = map[int]int{}
+	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummyResponseError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(nil, dummyResponseError).Once()
+	m.Mock(waitRetryDelay).Expects(dummyRequestObject, dummyRetryDelay).Returns(false).Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryDelay,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
+}
+
+func TestWaitRetryDelay_Elapsed(t *testing.T) {
+	// arrange
+	var dummyRequestObject = &http.Request{}
+
+	// SUT + act
+	var result = waitRetryDelay(
+		dummyRequestObject,
+		time.Millisecond,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestWaitRetryDelay_Cancelled(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRequestObject = (&http.Request{}).WithContext(dummyContext)
+	var startTime = time.Now()
+	time.AfterFunc(10*time.Millisecond, dummyCancel)
+
+	// SUT + act
+	var result = waitRetryDelay(
+		dummyRequestObject,
+		time.Hour,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Less(t, time.Since(startTime), time.Minute)
+}
+
 func TestGetHTTPTransport_NoClientCert(t *testing.T) {
 	// arrange
 	var dummySkipServerCertVerification = rand.IntN(100) < 50
@@ -342,7 +447,7 @@ func TestInitializeHTTPClients(t *testing.T) {
 	})).Returns(dummyHTTPTransport2).Once()
 
 	// SUT + act
-	initializeHTTPClients(
+	var result = initializeHTTPClients(
 		dummyWebcallTimeout,
 		dummySkipServerCertVerification,
 		dummyClientCert,
@@ -350,12 +455,13 @@ func TestInitializeHTTPClients(t *testing.T) {
 	)
 
 	// assert
-	assert.NotNil(t, httpClientWithCert)
-	assert.Equal(t, dummyHTTPTransport1, httpClientWithCert.Transport)
-	assert.Equal(t, dummyWebcallTimeout, httpClientWithCert.Timeout)
-	assert.NotNil(t, httpClientNoCert)
-	assert.Equal(t, dummyHTTPTransport2, httpClientNoCert.Transport)
-	assert.Equal(t, dummyWebcallTimeout, httpClientNoCert.Timeout)
+	assert.NotNil(t, result)
+	assert.NotNil(t, result.withCert)
+	assert.Equal(t, dummyHTTPTransport1, result.withCert.Transport)
+	assert.Equal(t, dummyWebcallTimeout, result.withCert.Timeout)
+	assert.NotNil(t, result.noCert)
+	assert.Equal(t, dummyHTTPTransport2, result.noCert.Transport)
+	assert.Equal(t, dummyWebcallTimeout, result.noCert.Timeout)
 }
 
 func TestWebREquestAddQuery_HappyPath(t *testing.T) {
@@ -732,6 +838,7 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 		dummySendClientCert,
 		dummyRetryDelay,
 		dummyDataReceivers,
+		nil,
 	}
 	var dummyRequestURL = "some request url"
 	var dummyRequest *http.Request
@@ -746,7 +853,7 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 	// expect
 	m.Mock(generateRequestURL).Expects(dummyURL, dummyQuery).Returns(dummyRequestURL).Once()
 	m.Mock(strings.NewReader).Expects(dummyPayload).Returns(dummyStingsReader).Once()
-	m.Mock(http.NewRequest).Expects(dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, dummyError).Once()
+	m.Mock(http.NewRequestWithContext).Expects(context.Background(), dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, dummyError).Once()
 
 	// SUT + act
 	var result, err = createHTTPRequest(
@@ -797,6 +904,7 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 		dummySendClientCert,
 		dummyRetryDelay,
 		dummyDataReceivers,
+		nil,
 	}
 	var dummyRequestURL = "some request url"
 	var dummyRequest = &http.Request{
@@ -816,7 +924,7 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 	// expect
 	m.Mock(generateRequestURL).Expects(dummyURL, dummyQuery).Returns(dummyRequestURL).Once()
 	m.Mock(strings.NewReader).Expects(dummyPayload).Returns(dummyStingsReader).Once()
-	m.Mock(http.NewRequest).Expects(dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, nil).Once()
+	m.Mock(http.NewRequestWithContext).Expects(context.Background(), dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, nil).Once()
 	m.Mock(logWebcallStart).Expects(dummySession, dummyMethod, dummyURL, dummyRequestURL).Returns().Once()
 	m.Mock(logWebcallRequest).Expects(dummySession, "Payload", "Content", dummyPayload).Returns().Once()
 	m.Mock(logWebcallRequest).Expects(dummySession, "Header", "Content", dummyHeaderContent).Returns().Once()
@@ -833,6 +941,85 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateHTTPRequest_Traced(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyMethod = "some method"
+	var dummyURL = "some URL"
+	var dummyPayload = "some payload"
+	var dummyHeader = map[string][]string{
+		"foo":  {"bar"},
+		"test": {"123", "456"},
+	}
+	var dummyQuery = map[string][]string{
+		"me":   {"god"},
+		"what": {"xyz", "abc"},
+	}
+	var dummyConnRetry = rand.Int()
+	var dummyHTTPRetry = map[int]int{
+		rand.Int(): rand.Int(),
+		rand.Int(): rand.Int(),
+	}
+	var dummySendClientCert = rand.IntN(100) < 50
+	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummyDataReceivers = []dataReceiver{
+		{0, 999, nil},
+	}
+	var dummySpan = &Span{TraceID: "some trace", SpanID: "some span"}
+	var dummyWebRequest = &webRequest{
+		dummySession,
+		dummyMethod,
+		dummyURL,
+		dummyPayload,
+		dummyQuery,
+		dummyHeader,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummySendClientCert,
+		dummyRetryDelay,
+		dummyDataReceivers,
+		dummySpan,
+	}
+	var dummyRequestURL = "some request url"
+	var dummyRequest = &http.Request{
+		RequestURI: "abc",
+	}
+	var dummyHeaderContent = "some header content"
+	var dummyCustomized = &http.Request{
+		RequestURI: "def",
+	}
+
+	// stub
+	var dummyStingsReader = strings.NewReader(dummyPayload)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(generateRequestURL).Expects(dummyURL, dummyQuery).Returns(dummyRequestURL).Once()
+	m.Mock(strings.NewReader).Expects(dummyPayload).Returns(dummyStingsReader).Once()
+	m.Mock(http.NewRequestWithContext).Expects(context.Background(), dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, nil).Once()
+	m.Mock(logWebcallStart).Expects(dummySession, dummyMethod, dummyURL, dummyRequestURL).Returns().Once()
+	m.Mock(logWebcallRequest).Expects(dummySession, "Payload", "Content", dummyPayload).Returns().Once()
+	m.Mock(logWebcallRequest).Expects(dummySession, "Header", "Content", dummyHeaderContent).Returns().Once()
+	m.Mock(marshalIgnoreError).Expects(gomocker.Anything()).Returns(dummyHeaderContent).Once()
+	m.Mock((*DefaultCustomization).WrapRequest).Expects(dummyCustomization, dummySession, dummyRequest).Returns(dummyCustomized).Once()
+
+	// SUT + act
+	var result, err = createHTTPRequest(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyCustomized, result)
+	assert.NoError(t, err)
+	assert.Equal(t, "00-some trace-some span-01", dummyRequest.Header.Get("traceparent"))
+	assert.Equal(t, "bar", dummyRequest.Header.Get("foo"))
+}
+
 func TestLogErrorResponse(t *testing.T) {
 	// arrange
 	var dummySession = &session{id: uuid.New()}
@@ -987,7 +1174,8 @@ func TestDoRequestProcessing_RequestError(t *testing.T) {
 
 func TestDoRequestProcessing_ResponseError(t *testing.T) {
 	// arrange
-	var dummySession = &session{id: uuid.New()}
+	var dummyClients = &httpClients{}
+	var dummySession = &session{id: uuid.New(), clients: dummyClients}
 	var dummyConnRetry = rand.Int()
 	var dummyHTTPRetry = map[int]int{
 		rand.Int(): rand.Int(),
@@ -1013,9 +1201,10 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 
 	// expect
 	m.Mock(createHTTPRequest).Expects(dummyWebRequest).Returns(dummyRequestObject, nil).Once()
-	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(getClientForRequest).Expects(dummyClients, dummySendClientCert).Returns(dummyHTTPClient).Once()
 	m.Mock(time.Now).Expects().Returns(dummyStartTime).Once()
 	m.Mock(clientDoWithRetry).Expects(dummyHTTPClient, dummyRequestObject, dummyConnRetry, dummyHTTPRetry, dummyRetryDelay).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(observeWebcall).Expects(dummyRequestObject, dummyResponseObject, dummyResponseError, dummyStartTime).Returns().Once()
 	m.Mock(logErrorResponse).Expects(dummySession, dummyResponseError, dummyStartTime).Returns().Once()
 
 	// SUT + act
@@ -1030,7 +1219,8 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 
 func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 	// arrange
-	var dummySession = &session{id: uuid.New()}
+	var dummyClients = &httpClients{}
+	var dummySession = &session{id: uuid.New(), clients: dummyClients}
 	var dummyConnRetry = rand.Int()
 	var dummyHTTPRetry = map[int]int{
 		rand.Int(): rand.Int(),
@@ -1055,9 +1245,10 @@ func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 
 	// expect
 	m.Mock(createHTTPRequest).Expects(dummyWebRequest).Returns(dummyRequestObject, nil).Once()
-	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(getClientForRequest).Expects(dummyClients, dummySendClientCert).Returns(dummyHTTPClient).Once()
 	m.Mock(time.Now).Expects().Returns(dummyStartTime).Once()
 	m.Mock(clientDoWithRetry).Expects(dummyHTTPClient, dummyRequestObject, dummyConnRetry, dummyHTTPRetry, dummyRetryDelay).Returns(dummyResponseObject, nil).Once()
+	m.Mock(observeWebcall).Expects(dummyRequestObject, dummyResponseObject, nil, dummyStartTime).Returns().Once()
 	m.Mock(logSuccessResponse).Expects(dummySession, dummyResponseObject, dummyStartTime).Returns().Once()
 
 	// SUT + act
@@ -1325,6 +1516,7 @@ func TestWebRequestProcess_NilWebRequestSession(t *testing.T) {
 
 func TestWebRequestProcess_Error_NilObject(t *testing.T) {
 	// arrange
+	var dummySpan = &Span{SpanID: "some span"}
 	var dummyResponseObject *http.Response
 	var dummyResponseError = errors.New("some error")
 
@@ -1337,12 +1529,15 @@ func TestWebRequestProcess_Error_NilObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(startWebcallSpan).Expects(sut).Returns(dummySpan).Once()
 	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(endWebcallSpan).Expects(sut, http.StatusInternalServerError, dummyResponseError).Returns().Once()
 
 	// act
 	var result, header, err = sut.Process()
 
 	// assert
+	assert.Equal(t, dummySpan, sut.span)
 	assert.Equal(t, http.StatusInternalServerError, result)
 	assert.Empty(t, header)
 	assert.Equal(t, dummyResponseError, err)
@@ -1350,6 +1545,7 @@ func TestWebRequestProcess_Error_NilObject(t *testing.T) {
 
 func TestWebRequestProcess_Error_ValidObject(t *testing.T) {
 	// arrange
+	var dummySpan = &Span{SpanID: "some span"}
 	var dummyStatusCode = rand.Int()
 	var dummyHeader = map[string][]string{
 		"foo":  {"bar"},
@@ -1370,12 +1566,15 @@ func TestWebRequestProcess_Error_ValidObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(startWebcallSpan).Expects(sut).Returns(dummySpan).Once()
 	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(endWebcallSpan).Expects(sut, dummyStatusCode, dummyResponseError).Returns().Once()
 
 	// act
 	var result, header, err = sut.Process()
 
 	// assert
+	assert.Equal(t, dummySpan, sut.span)
 	assert.Equal(t, dummyStatusCode, result)
 	assert.Equal(t, http.Header(dummyHeader), header)
 	assert.Equal(t, dummyResponseError, err)
@@ -1383,6 +1582,7 @@ func TestWebRequestProcess_Error_ValidObject(t *testing.T) {
 
 func TestWebRequestProcess_Success_NilObject(t *testing.T) {
 	// arrange
+	var dummySpan = &Span{SpanID: "some span"}
 	var dummyResponseObject *http.Response
 	var dummyResponseError error
 
@@ -1395,12 +1595,15 @@ func TestWebRequestProcess_Success_NilObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(startWebcallSpan).Expects(sut).Returns(dummySpan).Once()
 	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(endWebcallSpan).Expects(sut, 0, nil).Returns().Once()
 
 	// act
 	var result, header, err = sut.Process()
 
 	// assert
+	assert.Equal(t, dummySpan, sut.span)
 	assert.Zero(t, result)
 	assert.Empty(t, header)
 	assert.NoError(t, err)
@@ -1408,6 +1611,7 @@ func TestWebRequestProcess_Success_NilObject(t *testing.T) {
 
 func TestWebRequestProcess_Success_ValidObject(t *testing.T) {
 	// arrange
+	var dummySpan = &Span{SpanID: "some span"}
 	var dummyStatusCode = rand.Int()
 	var dummyHeader = map[string][]string{
 		"foo":  {"bar"},
@@ -1438,15 +1642,18 @@ func TestWebRequestProcess_Success_ValidObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(startWebcallSpan).Expects(sut).Returns(dummySpan).Once()
 	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
 	m.Mock(getDataTemplate).Expects(dummySession, dummyStatusCode, dummyDataReceivers).Returns(&dummyDataTemplate).Once()
 	m.Mock(parseResponse).Expects(dummySession, dummyBody, gomocker.Anything()).Returns(dummyParseError).SideEffects(
 		gomocker.ParamSideEffect(1, 3, func(value *string) { *value = dummyData })).Once()
+	m.Mock(endWebcallSpan).Expects(sut, dummyStatusCode, dummyParseError).Returns().Once()
 
 	// act
 	var result, header, err = sut.Process()
 
 	// assert
+	assert.Equal(t, dummySpan, sut.span)
 	assert.Equal(t, dummyData, dummyDataTemplate)
 	assert.Equal(t, dummyStatusCode, result)
 	assert.Equal(t, http.Header(dummyHeader), header)
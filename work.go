@@ -0,0 +1,262 @@
+package jobrunner
+
+import (
+	"iter"
+	"time"
+)
+
+// workItem is a single work item produced for a round in the work-queue mode
+type workItem struct {
+	sequence int
+	value    any
+}
+
+// produceWork executes the producer of the work items for the round, and returns the channel through which the produced items are fed to the worker instances, or nil if the producer failed
+func produceWork(app *application, round *round) (work <-chan *workItem) {
+	var session = initiateSession(
+		app,
+		round,
+		-1,
+		0,
+	)
+	logProcessEnter(
+		session,
+		app.name,
+		"ProduceWork",
+		"",
+	)
+	var phase = RunPhaseProduceWork
+	var err error
+	defer func() {
+		var recoverResult = recover()
+		if recoverResult != nil {
+			phase = RunPhasePanic
+		} else if err == nil {
+			return
+		}
+		err = finalizeSession(
+			session,
+			err,
+			recoverResult,
+		)
+		logProcessResponse(
+			session,
+			app.name,
+			"",
+			"%v",
+			err,
+		)
+		logProcessExit(
+			session,
+			app.name,
+			"Produced",
+			"%v",
+			0,
+		)
+		round.failed.Store(true)
+		recordError(
+			app,
+			newRunError(
+				round,
+				-1,
+				0,
+				phase,
+				err,
+			),
+		)
+		work = nil
+	}()
+	var items iter.Seq[any]
+	items, err = app.customization.ProduceWork(
+		session,
+	)
+	if err != nil {
+		return nil
+	}
+	var channel = make(chan *workItem)
+	go feedWork(
+		app,
+		session,
+		items,
+		channel,
+	)
+	return channel
+}
+
+// feedWork feeds the produced items one by one to the worker instances, until all items are fed or the round is cancelled
+func feedWork(app *application, session *session, items iter.Seq[any], work chan<- *workItem) {
+	var count = 0
+	defer func() {
+		var recoverResult = recover()
+		close(work)
+		var err error
+		if recoverResult != nil {
+			err = finalizeSession(
+				session,
+				nil,
+				recoverResult,
+			)
+			session.round.failed.Store(true)
+			recordError(
+				app,
+				newRunError(
+					session.round,
+					-1,
+					0,
+					RunPhasePanic,
+					err,
+				),
+			)
+		}
+		logProcessResponse(
+			session,
+			app.name,
+			"",
+			"%v",
+			err,
+		)
+		logProcessExit(
+			session,
+			app.name,
+			"Produced",
+			"%v",
+			count,
+		)
+	}()
+	if items == nil {
+		return
+	}
+	for value := range items {
+		select {
+		case work <- &workItem{sequence: count, value: value}:
+			count++
+		case <-session.Context().Done():
+			return
+		}
+	}
+}
+
+func processWorkItem(
+	session Session,
+	customization Customization,
+	item any,
+) (RunPhase, error) {
+	var preActionError = customization.PreAction(
+		session,
+	)
+	if preActionError != nil {
+		return RunPhasePreAction, preActionError
+	}
+	var consumeError = customization.ConsumeWork(
+		session,
+		item,
+	)
+	if consumeError != nil {
+		return RunPhaseConsumeWork, consumeError
+	}
+	var postActionError = customization.PostAction(
+		session,
+	)
+	if postActionError != nil {
+		return RunPhasePostAction, postActionError
+	}
+	return RunPhasePostAction, nil
+}
+
+// handleWorkItem processes a single work item within a dedicated child session of the worker instance, and returns the record of the processing
+func handleWorkItem(
+	app *application,
+	worker *session,
+	item *workItem,
+) (record *WorkItemRecord) {
+	var session = initiateSession(
+		app,
+		worker.round,
+		worker.index,
+		worker.reruns,
+	)
+	session.item = item
+	session.span = worker.span
+	logProcessEnter(
+		session,
+		app.name,
+		"WorkItem",
+		"",
+	)
+	logProcessRequest(
+		session,
+		app.name,
+		"WorkItem",
+		"%v",
+		item.sequence,
+	)
+	var phase = RunPhasePreAction
+	var err error
+	defer func(startTime time.Time) {
+		var recoverResult = recover()
+		if recoverResult != nil {
+			phase = RunPhasePanic
+		}
+		err = finalizeSession(
+			session,
+			err,
+			recoverResult,
+		)
+		var duration = time.Since(startTime)
+		logProcessResponse(
+			session,
+			app.name,
+			"",
+			"%v",
+			err,
+		)
+		logProcessExit(
+			session,
+			app.name,
+			"Duration",
+			"%s",
+			duration,
+		)
+		record = newWorkItemRecord(
+			worker.round,
+			worker.index,
+			worker.reruns,
+			item.sequence,
+			startTime,
+			duration,
+			phase,
+			err,
+		)
+	}(
+		time.Now().UTC(),
+	)
+	phase, err = superviseSession(
+		app,
+		session,
+	)
+	return nil
+}
+
+// processWorker consumes the work items of the round one by one until all are consumed, and returns the records of the processed items
+func processWorker(app *application, worker *session) []*WorkItemRecord {
+	var records = []*WorkItemRecord{}
+	for item := range worker.round.work {
+		var record = handleWorkItem(
+			app,
+			worker,
+			item,
+		)
+		if record.Err != nil {
+			worker.round.failed.Store(true)
+			recordError(
+				app,
+				record.Err,
+			)
+		}
+		records = append(
+			records,
+			record,
+		)
+	}
+	return records
+}
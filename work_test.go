@@ -0,0 +1,559 @@
+package jobrunner
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestProduceWork_Error(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+		errors:        newErrorRing(10),
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{id: uuid.New()}
+	var dummyProduceError = errors.New("some produce error")
+	var dummyFinalError = errors.New("some final error")
+	var dummyRunError = &RunError{Err: dummyFinalError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, -1, 0).Returns(dummySession).Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "ProduceWork", "").Returns().Once()
+	m.Mock((*customization).ProduceWork).Expects(dummyCustomization, dummySession).Returns(nil, dummyProduceError).Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyProduceError, nil).Returns(dummyFinalError).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Produced", "%v", 0).Returns().Once()
+	m.Mock(newRunError).Expects(dummyRound, -1, 0, RunPhaseProduceWork, dummyFinalError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var result = produceWork(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.True(t, dummyRound.failed.Load())
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}
+
+func TestProduceWork_Panic(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+		errors:        newErrorRing(10),
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{id: uuid.New()}
+	var dummyPanic = "some panic"
+	var dummyFinalError = errors.New("some final error")
+	var dummyRunError = &RunError{Err: dummyFinalError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, -1, 0).Returns(dummySession).Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "ProduceWork", "").Returns().Once()
+	m.Mock((*customization).ProduceWork).Expects(dummyCustomization, dummySession).Returns(nil, nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { panic(dummyPanic) })).Once()
+	m.Mock(finalizeSession).Expects(dummySession, nil, dummyPanic).Returns(dummyFinalError).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Produced", "%v", 0).Returns().Once()
+	m.Mock(newRunError).Expects(dummyRound, -1, 0, RunPhasePanic, dummyFinalError).Returns(dummyRunError).Once()
+
+	// SUT + act
+	var result = produceWork(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}
+
+func TestProduceWork_HappyPath(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{id: uuid.New()}
+	var dummyItems = slices.Values([]any{"some item"})
+	var fed = make(chan bool)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, -1, 0).Returns(dummySession).Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "ProduceWork", "").Returns().Once()
+	m.Mock((*customization).ProduceWork).Expects(dummyCustomization, dummySession).Returns(dummyItems, nil).Once()
+	m.Mock(feedWork).Expects(dummyApplication, dummySession, gomocker.Anything(), gomocker.Anything()).Returns().SideEffects(
+		gomocker.GeneralSideEffect(0, func() { fed <- true })).Once()
+
+	// SUT + act
+	var result = produceWork(
+		dummyApplication,
+		dummyRound,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	<-fed
+}
+
+func TestFeedWork_NilItems(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name: dummyName,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyWork = make(chan *workItem)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", nil).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Produced", "%v", 0).Returns().Once()
+
+	// SUT + act
+	feedWork(
+		dummyApplication,
+		dummySession,
+		nil,
+		dummyWork,
+	)
+
+	// assert
+	var _, open = <-dummyWork
+	assert.False(t, open)
+}
+
+func TestFeedWork_AllFed(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name: dummyName,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyItems = []any{"some item 1", "some item 2", "some item 3"}
+	var dummyWork = make(chan *workItem, len(dummyItems))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", nil).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Produced", "%v", 3).Returns().Once()
+
+	// SUT + act
+	feedWork(
+		dummyApplication,
+		dummySession,
+		slices.Values(dummyItems),
+		dummyWork,
+	)
+
+	// assert
+	var fed = []*workItem{}
+	for item := range dummyWork {
+		fed = append(fed, item)
+	}
+	assert.Equal(t, []*workItem{
+		{sequence: 0, value: "some item 1"},
+		{sequence: 1, value: "some item 2"},
+		{sequence: 2, value: "some item 3"},
+	}, fed)
+}
+
+func TestFeedWork_Cancelled(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name: dummyName,
+	}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummySession = &session{id: uuid.New(), ctx: dummyContext}
+	var dummyItems = []any{"some item 1", "some item 2"}
+	var dummyWork = make(chan *workItem, 1)
+
+	// stub
+	dummyCancel()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", nil).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Produced", "%v", gomocker.Matches(func(value any) bool {
+		return value.(int) <= 1
+	})).Returns().Once()
+
+	// SUT + act
+	feedWork(
+		dummyApplication,
+		dummySession,
+		slices.Values(dummyItems),
+		dummyWork,
+	)
+
+	// assert
+	var fed = []*workItem{}
+	for item := range dummyWork {
+		fed = append(fed, item)
+	}
+	assert.LessOrEqual(t, len(fed), 1)
+}
+
+func TestFeedWork_Panic(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyApplication = &application{
+		name:   dummyName,
+		errors: newErrorRing(10),
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummySession = &session{id: uuid.New(), round: dummyRound}
+	var dummyPanic = "some panic"
+	var dummyItems iter.Seq[any] = func(yield func(any) bool) {
+		if !yield("some item") {
+			return
+		}
+		panic(dummyPanic)
+	}
+	var dummyWork = make(chan *workItem, 1)
+	var dummyFinalError = errors.New("some final error")
+	var dummyRunError = &RunError{Err: dummyFinalError}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(finalizeSession).Expects(dummySession, nil, dummyPanic).Returns(dummyFinalError).Once()
+	m.Mock(newRunError).Expects(dummyRound, -1, 0, RunPhasePanic, dummyFinalError).Returns(dummyRunError).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Produced", "%v", 1).Returns().Once()
+
+	// SUT + act
+	feedWork(
+		dummyApplication,
+		dummySession,
+		dummyItems,
+		dummyWork,
+	)
+
+	// assert
+	var fed = []*workItem{}
+	for item := range dummyWork {
+		fed = append(fed, item)
+	}
+	assert.Equal(t, []*workItem{{sequence: 0, value: "some item"}}, fed)
+	assert.True(t, dummyRound.failed.Load())
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}
+
+func TestProcessWorkItem_PreActionError(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyItem = "some item"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).PreAction).Expects(dummyCustomization, dummySession).Returns(dummyError).Once()
+
+	// SUT + act
+	var phase, err = processWorkItem(
+		dummySession,
+		dummyCustomization,
+		dummyItem,
+	)
+
+	// assert
+	assert.Equal(t, RunPhasePreAction, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestProcessWorkItem_ConsumeWorkError(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyItem = "some item"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).PreAction).Expects(dummyCustomization, dummySession).Returns(nil).Once()
+	m.Mock((*customization).ConsumeWork).Expects(dummyCustomization, dummySession, dummyItem).Returns(dummyError).Once()
+
+	// SUT + act
+	var phase, err = processWorkItem(
+		dummySession,
+		dummyCustomization,
+		dummyItem,
+	)
+
+	// assert
+	assert.Equal(t, RunPhaseConsumeWork, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestProcessWorkItem_PostActionError(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyItem = "some item"
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).PreAction).Expects(dummyCustomization, dummySession).Returns(nil).Once()
+	m.Mock((*customization).ConsumeWork).Expects(dummyCustomization, dummySession, dummyItem).Returns(nil).Once()
+	m.Mock((*customization).PostAction).Expects(dummyCustomization, dummySession).Returns(dummyError).Once()
+
+	// SUT + act
+	var phase, err = processWorkItem(
+		dummySession,
+		dummyCustomization,
+		dummyItem,
+	)
+
+	// assert
+	assert.Equal(t, RunPhasePostAction, phase)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestProcessWorkItem_Success(t *testing.T) {
+	// arrange
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyItem = "some item"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*customization).PreAction).Expects(dummyCustomization, dummySession).Returns(nil).Once()
+	m.Mock((*customization).ConsumeWork).Expects(dummyCustomization, dummySession, dummyItem).Returns(nil).Once()
+	m.Mock((*customization).PostAction).Expects(dummyCustomization, dummySession).Returns(nil).Once()
+
+	// SUT + act
+	var phase, err = processWorkItem(
+		dummySession,
+		dummyCustomization,
+		dummyItem,
+	)
+
+	// assert
+	assert.Equal(t, RunPhasePostAction, phase)
+	assert.NoError(t, err)
+}
+
+func TestHandleWorkItem_Error(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyWorker = &session{
+		id:     uuid.New(),
+		index:  rand.IntN(100),
+		reruns: rand.IntN(100),
+		round:  dummyRound,
+		span:   &Span{SpanID: "some span"},
+	}
+	var dummyItem = &workItem{sequence: rand.IntN(100), value: "some item"}
+	var dummySession = &session{id: uuid.New()}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyDuration = time.Duration(rand.IntN(100))
+	var dummyProcessError = errors.New("some process error")
+	var dummyFinalError = errors.New("some final error")
+	var dummyRecord = &WorkItemRecord{Err: &RunError{Err: dummyFinalError}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, dummyWorker.index, dummyWorker.reruns).Returns(dummySession).Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "WorkItem", "").Returns().Once()
+	m.Mock(logProcessRequest).Expects(dummySession, dummyName, "WorkItem", "%v", dummyItem.sequence).Returns().Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(superviseSession).Expects(dummyApplication, dummySession).Returns(RunPhaseConsumeWork, dummyProcessError).Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyProcessError, nil).Returns(dummyFinalError).Once()
+	m.Mock(time.Since).Expects(dummyTimeNow).Returns(dummyDuration).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Duration", "%s", dummyDuration).Returns().Once()
+	m.Mock(newWorkItemRecord).Expects(dummyRound, dummyWorker.index, dummyWorker.reruns, dummyItem.sequence,
+		dummyTimeNow, dummyDuration, RunPhaseConsumeWork, dummyFinalError).Returns(dummyRecord).Once()
+
+	// SUT + act
+	var result = handleWorkItem(
+		dummyApplication,
+		dummyWorker,
+		dummyItem,
+	)
+
+	// assert
+	assert.Equal(t, dummyWorker.span, dummySession.span)
+	assert.Equal(t, dummyRecord, result)
+	assert.Equal(t, dummyItem, dummySession.item)
+}
+
+func TestHandleWorkItem_Panic(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	type customization struct {
+		Customization
+	}
+	var dummyCustomization = &customization{}
+	var dummyApplication = &application{
+		name:          dummyName,
+		customization: dummyCustomization,
+	}
+	var dummyRound = &round{id: uuid.New()}
+	var dummyWorker = &session{
+		id:     uuid.New(),
+		index:  rand.IntN(100),
+		reruns: rand.IntN(100),
+		round:  dummyRound,
+	}
+	var dummyItem = &workItem{sequence: rand.IntN(100), value: "some item"}
+	var dummySession = &session{id: uuid.New()}
+	var dummyTimeNow = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var dummyDuration = time.Duration(rand.IntN(100))
+	var dummyPanic = "some panic"
+	var dummyFinalError = errors.New("some final error")
+	var dummyRecord = &WorkItemRecord{Err: &RunError{Err: dummyFinalError}}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyRound, dummyWorker.index, dummyWorker.reruns).Returns(dummySession).Once()
+	m.Mock(logProcessEnter).Expects(dummySession, dummyName, "WorkItem", "").Returns().Once()
+	m.Mock(logProcessRequest).Expects(dummySession, dummyName, "WorkItem", "%v", dummyItem.sequence).Returns().Once()
+	m.Mock(time.Now).Expects().Returns(dummyTimeNow).Once()
+	m.Mock(superviseSession).Expects(dummyApplication, dummySession).Returns(RunPhaseConsumeWork, nil).SideEffects(
+		gomocker.GeneralSideEffect(0, func() { panic(dummyPanic) })).Once()
+	m.Mock(finalizeSession).Expects(dummySession, nil, dummyPanic).Returns(dummyFinalError).Once()
+	m.Mock(time.Since).Expects(dummyTimeNow).Returns(dummyDuration).Once()
+	m.Mock(logProcessResponse).Expects(dummySession, dummyName, "", "%v", dummyFinalError).Returns().Once()
+	m.Mock(logProcessExit).Expects(dummySession, dummyName, "Duration", "%s", dummyDuration).Returns().Once()
+	m.Mock(newWorkItemRecord).Expects(dummyRound, dummyWorker.index, dummyWorker.reruns, dummyItem.sequence,
+		dummyTimeNow, dummyDuration, RunPhasePanic, dummyFinalError).Returns(dummyRecord).Once()
+
+	// SUT + act
+	var result = handleWorkItem(
+		dummyApplication,
+		dummyWorker,
+		dummyItem,
+	)
+
+	// assert
+	assert.Equal(t, dummyRecord, result)
+}
+
+func TestProcessWorker(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		name:   "some name",
+		errors: newErrorRing(10),
+	}
+	var dummyWork = make(chan *workItem, 2)
+	var dummyWorker = &session{
+		id:    uuid.New(),
+		round: &round{work: dummyWork},
+	}
+	var dummyItems = []*workItem{
+		{sequence: 0, value: "some item 1"},
+		{sequence: 1, value: "some item 2"},
+	}
+	var dummyRunError = &RunError{Err: errors.New("some error")}
+	var dummyRecords = []*WorkItemRecord{
+		{Sequence: 0, Err: dummyRunError},
+		{Sequence: 1},
+	}
+
+	// stub
+	dummyWork <- dummyItems[0]
+	dummyWork <- dummyItems[1]
+	close(dummyWork)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(handleWorkItem).Expects(dummyApplication, dummyWorker, dummyItems[0]).Returns(dummyRecords[0]).Once()
+	m.Mock(handleWorkItem).Expects(dummyApplication, dummyWorker, dummyItems[1]).Returns(dummyRecords[1]).Once()
+
+	// SUT + act
+	var result = processWorker(
+		dummyApplication,
+		dummyWorker,
+	)
+
+	// assert
+	assert.Equal(t, dummyRecords, result)
+	assert.True(t, dummyWorker.round.failed.Load())
+	assert.Equal(t, []*RunError{dummyRunError}, dummyApplication.RunErrors())
+}